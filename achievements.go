@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// allTypes lists every Pokémon type, for the Type Master achievement
+var allTypes = []string{
+	"normal", "fire", "water", "electric", "grass", "ice",
+	"fighting", "poison", "ground", "flying", "psychic", "bug",
+	"rock", "ghost", "dragon", "dark", "steel", "fairy",
+}
+
+// kantoDexSize is how many Pokémon the Kanto pokedex lists; the Kanto
+// Complete check doesn't look at the dex until at least this many are caught
+const kantoDexSize = 151
+
+// achievement is a milestone checked after every catch. unlocked must be cheap
+// since it runs that often.
+type achievement struct {
+	id          string
+	title       string
+	description string
+	unlocked    func(ctx context.Context, cfg *config) bool
+}
+
+// achievements lists every achievement in the order the achievements command prints them
+var achievements = []achievement{
+	{"first-catch", "First Catch", "Catch your first Pokémon", func(ctx context.Context, cfg *config) bool {
+		return len(cfg.pokedex) >= 1
+	}},
+	{"ten-catches", "Collector", "Catch 10 Pokémon", func(ctx context.Context, cfg *config) bool {
+		return len(cfg.pokedex) >= 10
+	}},
+	{"type-master", "Type Master", "Catch a Pokémon of every type", func(ctx context.Context, cfg *config) bool {
+		return caughtEveryType(cfg)
+	}},
+	{"kanto-complete", "Kanto Complete", "Catch every Pokémon in the Kanto pokedex", func(ctx context.Context, cfg *config) bool {
+		return len(cfg.pokedex) >= kantoDexSize && kantoComplete(ctx, cfg)
+	}},
+}
+
+// caughtEveryType reports whether the pokedex covers all of allTypes
+func caughtEveryType(cfg *config) bool {
+	caught := make(map[string]bool, len(allTypes))
+	for _, p := range cfg.pokedex {
+		for _, t := range p.Types {
+			caught[t] = true
+		}
+	}
+	for _, t := range allTypes {
+		if !caught[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// kantoComplete reports whether every Pokémon in the Kanto pokedex has been
+// caught. The species list is fetched once per session and then checked in
+// memory; if it can't be fetched the check fails and is tried again next catch.
+func kantoComplete(ctx context.Context, cfg *config) bool {
+	if cfg.kantoSpecies == nil {
+		dex, err := getJSON[RegionalPokedexResponse](ctx, cfg, cfg.baseURL+"/pokedex/kanto")
+		if err != nil || len(dex.PokemonEntries) == 0 {
+			return false
+		}
+		cfg.kantoSpecies = make(map[string]bool, len(dex.PokemonEntries))
+		for _, entry := range dex.PokemonEntries {
+			cfg.kantoSpecies[entry.PokemonSpecies.Name] = true
+		}
+	}
+	for name := range cfg.kantoSpecies {
+		if _, ok := cfg.pokedex[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// checkAchievements unlocks any achievements newly earned, announcing each
+// once and saving the unlocked set
+func checkAchievements(ctx context.Context, cfg *config) {
+	if cfg.achievements == nil {
+		cfg.achievements = make(map[string]bool)
+	}
+	newlyUnlocked := false
+	for _, a := range achievements {
+		if cfg.achievements[a.id] || !a.unlocked(ctx, cfg) {
+			continue
+		}
+		cfg.achievements[a.id] = true
+		newlyUnlocked = true
+		flavorf(cfg, "*** Achievement unlocked: %s - %s ***\n", a.title, a.description)
+	}
+	if newlyUnlocked {
+		if err := saveAchievements(cfg); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// saveAchievements writes the unlocked achievement ids to cfg.unlockedPath, if set
+func saveAchievements(cfg *config) error {
+	if cfg.unlockedPath == "" {
+		return nil
+	}
+	ids := make([]string, 0, len(cfg.achievements))
+	for _, a := range achievements {
+		if cfg.achievements[a.id] {
+			ids = append(ids, a.id)
+		}
+	}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return fmt.Errorf("error encoding achievements: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.unlockedPath), 0o755); err != nil {
+		return fmt.Errorf("error creating achievements directory: %w", err)
+	}
+	if err := atomicWrite(cfg.unlockedPath, data); err != nil {
+		return fmt.Errorf("error writing achievements: %w", err)
+	}
+	return nil
+}
+
+// loadAchievements reads achievements written by saveAchievements. A missing file means none are unlocked.
+func loadAchievements(path string) (map[string]bool, error) {
+	unlocked := make(map[string]bool)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return unlocked, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading achievements: %w", err)
+	}
+	var ids []string
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, fmt.Errorf("error decoding achievements: %w", err)
+	}
+	for _, id := range ids {
+		unlocked[id] = true
+	}
+	return unlocked, nil
+}
+
+// commandAchievements lists every achievement and whether it is unlocked
+func commandAchievements(ctx context.Context, cfg *config, args ...[]string) error {
+	count := 0
+	for _, a := range achievements {
+		mark := " "
+		if cfg.achievements[a.id] {
+			mark = "x"
+			count++
+		}
+		fmt.Printf("[%s] %s - %s\n", mark, a.title, a.description)
+	}
+	fmt.Printf("Unlocked %d of %d\n", count, len(achievements))
+	return nil
+}
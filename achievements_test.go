@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAchievements(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.unlockedPath = filepath.Join(t.TempDir(), "achievements.json")
+	catch := func(p Pokemon) string {
+		return captureOutput(t, func() {
+			throwBall(context.Background(), cfg, p, defaultBall, 100)
+		})
+	}
+
+	out := catch(Pokemon{Name: "mon-1"})
+	if !strings.Contains(out, "Achievement unlocked: First Catch") {
+		t.Errorf("expected First Catch on the first catch, got %q", out)
+	}
+	if out := catch(Pokemon{Name: "mon-2"}); strings.Contains(out, "Achievement unlocked") {
+		t.Errorf("expected each banner only once, got %q", out)
+	}
+	for i := 3; i <= 9; i++ {
+		catch(Pokemon{Name: fmt.Sprintf("mon-%d", i)})
+	}
+	if cfg.achievements["ten-catches"] {
+		t.Fatal("Collector should need 10 catches")
+	}
+	if out := catch(Pokemon{Name: "mon-10"}); !strings.Contains(out, "Achievement unlocked: Collector") {
+		t.Errorf("expected Collector on the tenth catch, got %q", out)
+	}
+
+	loaded, err := loadAchievements(cfg.unlockedPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded["first-catch"] || !loaded["ten-catches"] || len(loaded) != 2 {
+		t.Errorf("expected the unlocked achievements to be saved, got %v", loaded)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("achievements", cfg)
+	})
+	for _, want := range []string{"[x] First Catch", "[x] Collector", "[ ] Type Master", "Unlocked 2 of 4"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+}
+
+func TestTypeMasterAchievement(t *testing.T) {
+	cfg := newTestConfig(t)
+	for _, typeName := range allTypes[1:] {
+		cfg.pokedex[typeName+"-mon"] = Pokemon{Name: typeName + "-mon", Types: []string{typeName}}
+	}
+	if caughtEveryType(cfg) {
+		t.Fatal("expected Type Master to need a normal type")
+	}
+
+	out := captureOutput(t, func() {
+		throwBall(context.Background(), cfg, Pokemon{Name: "rattata", Types: []string{"normal"}}, defaultBall, 100)
+	})
+	if !strings.Contains(out, "Achievement unlocked: Type Master") {
+		t.Errorf("expected Type Master once every type is caught, got %q", out)
+	}
+}
+
+func TestKantoCompleteFetchesDexOnce(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		io.WriteString(w, `{"name":"kanto","pokemon_entries":[
+			{"pokemon_species":{"name":"mon-1"}},
+			{"pokemon_species":{"name":"mew"}}
+		]}`)
+	}))
+	defer srv.Close()
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	for i := 1; i <= kantoDexSize; i++ {
+		name := fmt.Sprintf("mon-%d", i)
+		cfg.pokedex[name] = Pokemon{Name: name}
+	}
+
+	captureOutput(t, func() {
+		checkAchievements(context.Background(), cfg)
+		// Drop the cached response, so only the in-memory species set avoids a request
+		cfg.cache.Delete(srv.URL + "/pokedex/kanto")
+		checkAchievements(context.Background(), cfg)
+	})
+	if cfg.achievements["kanto-complete"] {
+		t.Fatal("Kanto Complete should need mew")
+	}
+
+	cfg.pokedex["mew"] = Pokemon{Name: "mew"}
+	out := captureOutput(t, func() {
+		checkAchievements(context.Background(), cfg)
+	})
+	if !strings.Contains(out, "Achievement unlocked: Kanto Complete") {
+		t.Errorf("expected Kanto Complete once mew is caught, got %q", out)
+	}
+	if requests != 1 {
+		t.Errorf("expected the Kanto pokedex fetched once, got %d requests", requests)
+	}
+}
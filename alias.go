@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+)
+
+// builtinAliases are shorthands available without any configuration
+var builtinAliases = map[string]string{
+	"c": "catch",
+	"e": "explore",
+	"m": "map",
+}
+
+// userConfig is the optional JSON config file in the data directory, e.g.
+//
+//	{"aliases": {"x": "explore", "c": "catch"}}
+type userConfig struct {
+	Aliases map[string]string `json:"aliases"`
+}
+
+// loadUserConfig reads the config file at path; a missing file is an empty config
+func loadUserConfig(path string) (userConfig, error) {
+	var uc userConfig
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return uc, nil
+	}
+	if err != nil {
+		return uc, fmt.Errorf("error reading config: %w", err)
+	}
+	if err := json.Unmarshal(data, &uc); err != nil {
+		return uc, fmt.Errorf("error parsing config %s: %w", path, err)
+	}
+	return uc, nil
+}
+
+// validAliases returns the user aliases that can be used, plus an error for
+// each one that can't: aliases may not shadow a command or point at anything
+// but a registered command
+func validAliases(aliases map[string]string) (map[string]string, []error) {
+	valid := make(map[string]string, len(aliases))
+	var errs []error
+	for name, target := range aliases {
+		switch {
+		case name == "" || name != strings.ToLower(name) || strings.ContainsAny(name, " \t"):
+			errs = append(errs, fmt.Errorf("invalid alias name %q", name))
+		case reservedNames[name]:
+			errs = append(errs, fmt.Errorf("alias name %q is reserved", name))
+		case Commands[name].callback != nil:
+			errs = append(errs, fmt.Errorf("alias %q would shadow the %s command", name, name))
+		case Commands[target].callback == nil:
+			errs = append(errs, fmt.Errorf("alias %q points at unknown command %q", name, target))
+		default:
+			valid[name] = target
+		}
+	}
+	// Map order is random; keep warnings stable from run to run
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Error() < errs[j].Error() })
+	return valid, errs
+}
+
+// effectiveAliases merges the built-in aliases with cfg's user aliases, which win
+func effectiveAliases(cfg *config) map[string]string {
+	merged := make(map[string]string, len(builtinAliases)+len(cfg.aliases))
+	for name, target := range builtinAliases {
+		merged[name] = target
+	}
+	for name, target := range cfg.aliases {
+		merged[name] = target
+	}
+	return merged
+}
+
+// resolveCommand returns the command name that name refers to, following an
+// alias if name isn't a command itself
+func resolveCommand(cfg *config, name string) string {
+	if _, ok := Commands[name]; ok {
+		return name
+	}
+	if target, ok := cfg.aliases[name]; ok {
+		return target
+	}
+	if target, ok := builtinAliases[name]; ok {
+		return target
+	}
+	return name
+}
+
+// commandAlias lists the effective aliases
+func commandAlias(ctx context.Context, cfg *config, args ...[]string) error {
+	printAliases(cfg)
+	return nil
+}
+
+// printAliases prints the effective aliases, sorted by name
+func printAliases(cfg *config) {
+	aliases := effectiveAliases(cfg)
+	names := make([]string, 0, len(aliases))
+	for name := range aliases {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Println("Aliases:")
+	for _, name := range names {
+		fmt.Printf("  %s -> %s\n", name, aliases[name])
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUserAliases(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	config := `{"aliases": {"x": "explore", "c": "catch", "map": "mapb", "oops": "fly"}}`
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	uc, err := loadUserConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aliases, errs := validAliases(uc.Aliases)
+	if len(aliases) != 2 || aliases["x"] != "explore" || aliases["c"] != "catch" {
+		t.Errorf("expected only x and c to be valid, got %v", aliases)
+	}
+	if len(errs) != 2 ||
+		!strings.Contains(errs[0].Error(), `"map" would shadow`) ||
+		!strings.Contains(errs[1].Error(), `unknown command "fly"`) {
+		t.Errorf("expected shadowing and unknown target errors, got %v", errs)
+	}
+
+	srv := newTestServer(t, map[string]string{
+		"/location-area/canalave-city-area": `{"pokemon_encounters":[{"pokemon":{"name":"tentacool"}}]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.aliases = aliases
+
+	out := captureOutput(t, func() {
+		processInput("x canalave-city-area", cfg)
+	})
+	if !strings.Contains(out, "tentacool") {
+		t.Errorf("expected x to run explore, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("alias", cfg)
+	})
+	for _, want := range []string{"c -> catch", "e -> explore", "x -> explore"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected alias to list %q, got %q", want, out)
+		}
+	}
+}
+
+func TestLoadUserConfigMissing(t *testing.T) {
+	uc, err := loadUserConfig(filepath.Join(t.TempDir(), "config.json"))
+	if err != nil || len(uc.Aliases) != 0 {
+		t.Errorf("expected an empty config, got %+v, %v", uc, err)
+	}
+}
+
+func TestBuiltinAliases(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/location-area":                    `{"results":[{"name":"canalave-city-area"}]}`,
+		"/location-area/canalave-city-area": `{"pokemon_encounters":[{"pokemon":{"name":"tentacool"}}]}`,
+	})
+	run := func(input string) string {
+		cfg := newTestConfig(t)
+		cfg.baseURL = srv.URL
+		return captureOutput(t, func() {
+			processInput(input, cfg)
+		})
+	}
+
+	if got, want := run("m"), run("map"); got != want || !strings.Contains(got, "canalave-city-area") {
+		t.Errorf("expected m to run map, got %q, want %q", got, want)
+	}
+	if got, want := run("e canalave-city-area"), run("explore canalave-city-area"); got != want || !strings.Contains(got, "tentacool") {
+		t.Errorf("expected e to forward its argument to explore, got %q, want %q", got, want)
+	}
+
+	out := run("help")
+	for _, want := range []string{"Aliases:", "m -> map", "e -> explore", "c -> catch"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected help to list %q, got %q", want, out)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// popFlag removes every "--name" from args and reports whether it was present
+func popFlag(args []string, name string) ([]string, bool) {
+	flag := "--" + name
+	rest := make([]string, 0, len(args))
+	found := false
+	for _, arg := range args {
+		if arg == flag {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
+
+// popFlagValue removes "--name value" or "--name=value" from args and returns the value
+func popFlagValue(args []string, name string) ([]string, string, bool, error) {
+	flag := "--" + name
+	rest := make([]string, 0, len(args))
+	value := ""
+	found := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == flag:
+			if i+1 >= len(args) {
+				return args, "", false, fmt.Errorf("%s requires a value", flag)
+			}
+			value = args[i+1]
+			found = true
+			i++
+		case strings.HasPrefix(arg, flag+"="):
+			value = strings.TrimPrefix(arg, flag+"=")
+			found = true
+		default:
+			rest = append(rest, arg)
+		}
+	}
+	return rest, value, found, nil
+}
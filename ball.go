@@ -0,0 +1,51 @@
+package main
+
+import "fmt"
+
+// defaultBall is thrown when catch is given no --ball
+const defaultBall = "pokeball"
+
+// ballMultipliers scales the catch chance for each ball, in percent. A
+// masterball isn't listed because it never misses.
+var ballMultipliers = map[string]int{
+	"pokeball":  100,
+	"greatball": 150,
+	"ultraball": 200,
+}
+
+// ballNames is how each ball is named when thrown, with its article
+var ballNames = map[string]string{
+	"pokeball":   "a Pokeball",
+	"greatball":  "a Great Ball",
+	"ultraball":  "an Ultra Ball",
+	"masterball": "a Master Ball",
+}
+
+// ballName returns ball's name for "Throwing <name> at ...", defaulting to a Pokeball
+func ballName(ball string) string {
+	if name, ok := ballNames[ball]; ok {
+		return name
+	}
+	return ballNames[defaultBall]
+}
+
+// validBall reports an error for anything but a known ball
+func validBall(ball string) error {
+	if _, ok := ballMultipliers[ball]; ok || ball == "masterball" {
+		return nil
+	}
+	return fmt.Errorf("unknown ball %q, use pokeball, greatball, ultraball or masterball", ball)
+}
+
+// ballChance returns chance thrown with ball, kept within 1-90% except for a
+// masterball, which always catches
+func ballChance(ball string, chance int) int {
+	if ball == "masterball" {
+		return 100
+	}
+	multiplier, ok := ballMultipliers[ball]
+	if !ok {
+		return chance
+	}
+	return clampChance(chance * multiplier / 100)
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestBallChance(t *testing.T) {
+	tests := []struct {
+		ball   string
+		chance int
+		want   int
+	}{
+		{"pokeball", 5, 5},
+		{"greatball", 5, 7},
+		{"ultraball", 5, 10},
+		{"ultraball", 60, 90},
+		{"masterball", 1, 100},
+	}
+	for _, tt := range tests {
+		if got := ballChance(tt.ball, tt.chance); got != tt.want {
+			t.Errorf("ballChance(%q, %d) = %d, want %d", tt.ball, tt.chance, got, tt.want)
+		}
+	}
+}
+
+func TestCatchWithBall(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/dragonite": `{"name":"dragonite","base_experience":90}`,
+	})
+	catch := func(input string) string {
+		cfg := newTestConfig(t)
+		cfg.baseURL = srv.URL
+		cfg.rng = rand.New(rand.NewSource(3)) // rolls 9 against 5%, or 10% with an ultra ball
+		return captureOutput(t, func() {
+			processInput(input, cfg)
+		})
+	}
+
+	if out := catch("catch dragonite"); !strings.Contains(out, "dragonite escaped!") {
+		t.Errorf("expected a Pokéball to miss, got %q", out)
+	}
+	if out := catch("catch dragonite --ball=ultraball"); !strings.Contains(out, "Throwing an Ultra Ball at dragonite...") || !strings.Contains(out, "You caught dragonite!") {
+		t.Errorf("expected an ultra ball to catch, got %q", out)
+	}
+	if out := catch("catch dragonite --ball=heavyball"); !strings.Contains(out, `unknown ball "heavyball"`) {
+		t.Errorf("expected an unknown ball to be rejected, got %q", out)
+	}
+}
+
+func TestMasterBallSkipsTiming(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/mewtwo": `{"name":"mewtwo","base_experience":340}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.timing = true
+	cfg.interactive = true
+	cfg.lines = make(chan string) // nobody presses Enter, so timing would say too slow
+
+	out := captureOutput(t, func() {
+		processInput("catch mewtwo --ball=masterball", cfg)
+	})
+	if strings.Contains(out, "Too slow") || !strings.Contains(out, "You caught mewtwo!") {
+		t.Errorf("expected a Master Ball to catch without the timing minigame, got %q", out)
+	}
+	if got := safariChance(100); got != 100 {
+		t.Errorf("expected a guaranteed catch to stay at 100%% in the Safari Zone, got %d", got)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// cancelKey is the line that cancels a running batch operation
+const cancelKey = "q"
+
+// runBatch runs fn with a context derived from the command's ctx, so it is
+// also cancelled when the user enters cancelKey or when cfg.commandTimeout
+// elapses. fn should pass it to every request so a hung one is abandoned
+// too. The watcher only runs in interactive mode, so piped input is never
+// consumed, and it always exits before runBatch returns. While it runs it is
+// the only reader of cfg.lines: cfg.inBatch turns off timed throws.
+func runBatch(ctx context.Context, cfg *config, fn func(ctx context.Context) error) error {
+	if cfg.commandTimeout > 0 {
+		var stop context.CancelFunc
+		ctx, stop = context.WithTimeout(ctx, cfg.commandTimeout)
+		defer stop()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if !cfg.interactive || cfg.lines == nil {
+		return fn(ctx)
+	}
+
+	fmt.Printf("(enter %s to cancel)\n", cancelKey)
+	cfg.inBatch = true
+	defer func() { cfg.inBatch = false }()
+	watcherDone := make(chan struct{})
+	go func() {
+		defer close(watcherDone)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case line, ok := <-cfg.lines:
+				if !ok {
+					return
+				}
+				if strings.TrimSpace(strings.ToLower(line)) == cancelKey {
+					cancel()
+					return
+				}
+				fmt.Printf("(busy - enter %s to cancel)\n", cancelKey)
+			}
+		}
+	}()
+
+	err := fn(ctx)
+	cancel()
+	<-watcherDone
+	return err
+}
+
+// stopReason describes why a batch context ended, for "<reason>, caught N so far"
+func stopReason(ctx context.Context) string {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "command timed out"
+	}
+	return "cancelled"
+}
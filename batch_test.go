@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCatchRangeCancel(t *testing.T) {
+	lines := make(chan string)
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		id := strings.TrimPrefix(r.URL.Path, "/pokemon/")
+		if id == "2" {
+			// The user presses q while the second Pokémon is being fetched
+			lines <- "q"
+			time.Sleep(50 * time.Millisecond)
+		}
+		fmt.Fprintf(w, `{"id":%s,"name":"mon-%s","base_experience":0}`, id, id)
+	}))
+	defer srv.Close()
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.interactive = true
+	cfg.lines = lines
+
+	out := captureOutput(t, func() {
+		processInput("catch --range 1 50", cfg)
+	})
+
+	want := fmt.Sprintf("cancelled, caught %d so far", len(cfg.pokedex))
+	if !strings.Contains(out, want) {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+	if requests != 2 {
+		t.Errorf("expected the batch to stop after 2 requests, got %d", requests)
+	}
+	if strings.Contains(out, "Range 1-50:") {
+		t.Errorf("cancelled batch should not print the full summary, got %q", out)
+	}
+}
+
+func TestCatchRangeTimeoutAbortsHungRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done() // never answers
+	}))
+	defer srv.Close()
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.commandTimeout = 50 * time.Millisecond
+	cfg.maxRetries = 3
+	cfg.retryBackoff = time.Second
+
+	start := time.Now()
+	out := captureOutput(t, func() {
+		processInput("catch --range 1 3", cfg)
+	})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the timeout to abandon the request and its retries, took %s", elapsed)
+	}
+	if !strings.Contains(out, "command timed out, caught 0 so far") || strings.Contains(out, "Could not find") {
+		t.Errorf("expected only the timeout reported, got %q", out)
+	}
+}
+
+func TestRunBatchWatcherExits(t *testing.T) {
+	lines := make(chan string)
+	cfg := newTestConfig(t)
+	cfg.interactive = true
+	cfg.lines = lines
+
+	captureOutput(t, func() {
+		runBatch(context.Background(), cfg, func(ctx context.Context) error { return nil })
+	})
+
+	// With the watcher gone, nobody but us can receive from lines
+	go func() { lines <- "pokedex" }()
+	select {
+	case line := <-lines:
+		if line != "pokedex" {
+			t.Errorf("unexpected line %q", line)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("line was swallowed by a leaked watcher")
+	}
+}
+
+func TestRunBatchNonInteractive(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.lines = make(chan string)
+
+	called := false
+	out := captureOutput(t, func() {
+		runBatch(context.Background(), cfg, func(ctx context.Context) error {
+			called = ctx.Err() == nil
+			return nil
+		})
+	})
+	if !called {
+		t.Error("expected fn to run with a live context")
+	}
+	if out != "" {
+		t.Errorf("non-interactive batches should not print a cancel hint, got %q", out)
+	}
+}
+
+func TestCatchRangeCommandTimeout(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(30 * time.Millisecond)
+		id := strings.TrimPrefix(r.URL.Path, "/pokemon/")
+		fmt.Fprintf(w, `{"id":%s,"name":"mon-%s","base_experience":0}`, id, id)
+	}))
+	defer srv.Close()
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.commandTimeout = 100 * time.Millisecond
+
+	out := captureOutput(t, func() {
+		processInput("catch --range 1 100", cfg)
+	})
+
+	if !strings.Contains(out, "command timed out, caught") {
+		t.Errorf("expected the watchdog to stop the batch, got %q", out)
+	}
+	if strings.Contains(out, "Range 1-100:") {
+		t.Errorf("timed-out batch should not print the full summary, got %q", out)
+	}
+	if n := requests.Load(); n >= 100 {
+		t.Errorf("expected the batch to stop early, got %d requests", n)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// baseStatTotal returns the sum of p's base stats, or false for entries saved
+// without stats
+func baseStatTotal(p Pokemon) (int, bool) {
+	if len(p.Stats) == 0 {
+		return 0, false
+	}
+	total := 0
+	for _, stat := range p.Stats {
+		total += stat.Value
+	}
+	return total, true
+}
+
+// formatBST returns p's base stat total, or "unknown" for entries without stats
+func formatBST(p Pokemon) string {
+	if total, ok := baseStatTotal(p); ok {
+		return strconv.Itoa(total)
+	}
+	return "unknown"
+}
+
+// rankByBST sorts list by base stat total, highest first and ties by name,
+// with entries lacking stats last
+func rankByBST(list []Pokemon) {
+	sort.SliceStable(list, func(i, j int) bool {
+		bi, okI := baseStatTotal(list[i])
+		bj, okJ := baseStatTotal(list[j])
+		if okI != okJ {
+			return okI
+		}
+		if bi != bj {
+			return bi > bj
+		}
+		return list[i].Name < list[j].Name
+	})
+}
+
+// commandBST ranks caught Pokémon by base stat total
+func commandBST(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(cfg.pokedex) == 0 {
+		fmt.Println("You haven't caught any Pokémon yet!")
+		return nil
+	}
+	list := sortedPokedex(cfg.pokedex)
+	rankByBST(list)
+	for i, p := range list {
+		fmt.Printf("%d. %s: %s\n", i+1, p.Name, formatBST(p))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestBaseStatTotal(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pikachu": `{"name":"pikachu","stats":[
+			{"base_stat":35,"stat":{"name":"hp"}},
+			{"base_stat":55,"stat":{"name":"attack"}},
+			{"base_stat":40,"stat":{"name":"defense"}},
+			{"base_stat":50,"stat":{"name":"special-attack"}},
+			{"base_stat":50,"stat":{"name":"special-defense"}},
+			{"base_stat":90,"stat":{"name":"speed"}}
+		]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	pokeResp, err := fetchPokemon(context.Background(), cfg, "pikachu")
+	if err != nil {
+		t.Fatalf("fetchPokemon: %v", err)
+	}
+	pikachu := pokeResp.toPokemon()
+	if total, ok := baseStatTotal(pikachu); !ok || total != 320 {
+		t.Errorf("expected a BST of 320, got %d, %v", total, ok)
+	}
+
+	cfg.pokedex = map[string]Pokemon{
+		"pikachu": pikachu,
+		"raichu":  {Name: "raichu", Stats: []Stat{{Name: "hp", Value: 485}}},
+		"jolteon": {Name: "jolteon", Stats: []Stat{{Name: "hp", Value: 320}}},
+		"legacy":  {Name: "legacy"},
+	}
+	out := captureOutput(t, func() {
+		processInput("bst", cfg)
+		processInput("inspect legacy", cfg)
+	})
+	want := "1. raichu: 485\n2. jolteon: 320\n3. pikachu: 320\n4. legacy: unknown\n"
+	if !strings.HasPrefix(out, want) {
+		t.Errorf("expected ranking %q, got %q", want, out)
+	}
+	if !strings.Contains(out, "Base stat total: unknown\n") {
+		t.Errorf("expected inspect to show an unknown BST, got %q", out)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/deoreal/pokedexcli/internal/pokecache"
+)
+
+// commandCache dispatches the cache subcommands
+func commandCache(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) == 0 {
+		hits, misses := cfg.cache.Stats()
+		fmt.Printf("Cache: %s hits, %s misses, %s entries\n", formatCount(cfg, hits), formatCount(cfg, misses), formatCount(cfg, cfg.cache.Len()))
+		return nil
+	}
+
+	sub, rest := strings.ToLower(args[0][0]), args[0][1:]
+	switch sub {
+	case "stats":
+		fmt.Printf("Entries: %s\n", formatCount(cfg, cfg.cache.Len()))
+		if max := cfg.cache.MaxBytes(); max > 0 {
+			fmt.Printf("Size: %s / %s bytes\n", formatCount(cfg, cfg.cache.SizeBytes()), formatCount(cfg, max))
+		} else {
+			fmt.Printf("Size: %s bytes\n", formatCount(cfg, cfg.cache.SizeBytes()))
+		}
+		if m := findMetered(cfg.cache); m != nil {
+			hits, misses := cfg.cache.Stats()
+			fmt.Printf("Hits: %s, misses: %s, writes: %s\n",
+				formatCount(cfg, hits), formatCount(cfg, misses), formatCount(cfg, int(m.writes.Load())))
+		}
+	case "export":
+		if len(rest) == 0 {
+			return userErrorf("You must provide a file to export to")
+		}
+		if err := saveCache(cfg.cache, rest[0]); err != nil {
+			return err
+		}
+		fmt.Printf("Exported %d cache entries to %s\n", cfg.cache.Len(), rest[0])
+	case "import":
+		if len(rest) == 0 {
+			return userErrorf("You must provide a file to import from")
+		}
+		entries, err := loadCache(rest[0])
+		if err != nil {
+			return err
+		}
+		added := cfg.cache.Merge(entries)
+		fmt.Printf("Imported %d of %d cache entries from %s\n", added, len(entries), rest[0])
+	default:
+		return userErrorf("Unknown cache subcommand: %s", sub)
+	}
+	return nil
+}
+
+// saveCache writes every cache entry to path as JSON
+func saveCache(cache Cacher, path string) error {
+	data, err := json.Marshal(cache.GetCacheMap())
+	if err != nil {
+		return fmt.Errorf("error encoding cache: %w", err)
+	}
+	if err := atomicWrite(path, data); err != nil {
+		return fmt.Errorf("error writing cache file: %w", err)
+	}
+	return nil
+}
+
+// loadCache reads cache entries previously written by saveCache
+func loadCache(path string) (map[string]pokecache.CacheEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cache file: %w", err)
+	}
+	var entries map[string]pokecache.CacheEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("error decoding cache file: %w", err)
+	}
+	return entries, nil
+}
+
+// dumpCache writes a table of every cached URL with its age at now and value size
+func dumpCache(w io.Writer, cache Cacher, now time.Time) error {
+	entries := cache.GetCacheMap()
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "URL\tAge\tBytes\n")
+	for _, key := range keys {
+		entry := entries[key]
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", key, now.Sub(entry.CreatedAt).Round(time.Second), len(entry.Val))
+	}
+	fmt.Fprintf(tw, "%d entries\t\t%d\n", len(keys), cache.SizeBytes())
+	return tw.Flush()
+}
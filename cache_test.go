@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deoreal/pokedexcli/internal/pokecache"
+)
+
+func TestCacheExportImport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	cfg := newTestConfig(t)
+	cfg.cache.Add("https://example.test/a", []byte(`{"name":"a"}`))
+	cfg.cache.Add("https://example.test/b", []byte(`{"name":"b"}`))
+
+	out := captureOutput(t, func() {
+		processInput("cache export "+path, cfg)
+	})
+	if !strings.Contains(out, "Exported 2 cache entries") {
+		t.Fatalf("unexpected export output %q", out)
+	}
+
+	// A fresh config stands in for a cleared cache
+	cfg = newTestConfig(t)
+	cfg.cache.Add("https://example.test/a", []byte(`{"name":"local"}`))
+
+	out = captureOutput(t, func() {
+		processInput("cache import "+path, cfg)
+	})
+	if !strings.Contains(out, "Imported 1 of 2 cache entries") {
+		t.Errorf("unexpected import output %q", out)
+	}
+
+	if val, found := cfg.cache.Get("https://example.test/b"); !found || string(val) != `{"name":"b"}` {
+		t.Errorf("expected imported entry b, got %q (found=%v)", val, found)
+	}
+	if val, _ := cfg.cache.Get("https://example.test/a"); string(val) != `{"name":"local"}` {
+		t.Errorf("import should merge, not replace; got %q", val)
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.cache.Add("https://example.test/a", []byte("12345"))
+
+	out := captureOutput(t, func() {
+		processInput("cache stats", cfg)
+	})
+	if !strings.Contains(out, "Entries: 1") || !strings.Contains(out, "Size: 5 bytes") {
+		t.Errorf("unexpected cache stats output %q", out)
+	}
+}
+
+func TestCacheCommandHitsMisses(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pikachu": `{"name":"pikachu"}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	out := captureOutput(t, func() {
+		processInput("catch pikachu --min-chance 100", cfg)
+		processInput("catch pikachu --min-chance 100", cfg)
+		processInput("cache", cfg)
+	})
+	if !strings.Contains(out, "Cache: 1 hits, 1 misses, 1 entries") {
+		t.Errorf("expected cache counters, got %q", out)
+	}
+}
+
+func TestDumpCache(t *testing.T) {
+	now := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+	cache := pokecache.NewCache(time.Hour)
+	t.Cleanup(cache.Stop)
+	cache.SetClock(func() time.Time { return now.Add(-90 * time.Second) })
+	cache.Add("https://pokeapi.co/api/v2/pokemon/pikachu", make([]byte, 120))
+	cache.SetClock(func() time.Time { return now.Add(-5 * time.Second) })
+	cache.Add("https://pokeapi.co/api/v2/location-area", make([]byte, 40))
+
+	var buf bytes.Buffer
+	if err := dumpCache(&buf, cache, now); err != nil {
+		t.Fatalf("dumpCache: %v", err)
+	}
+	want := "URL                                        Age    Bytes\n" +
+		"https://pokeapi.co/api/v2/location-area    5s     40\n" +
+		"https://pokeapi.co/api/v2/pokemon/pikachu  1m30s  120\n" +
+		"2 entries                                         160\n"
+	if buf.String() != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, buf.String())
+	}
+}
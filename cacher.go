@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/deoreal/pokedexcli/internal/pokecache"
+)
+
+// Cacher is the request cache as the CLI uses it. *pokecache.Cache implements
+// it; the decorators below wrap any Cacher to add metrics or logging without
+// teaching pokecache about either. Decorators embed the Cacher they wrap, so
+// methods they don't care about are delegated as is.
+type Cacher interface {
+	Add(key string, val []byte)
+	Get(key string) ([]byte, bool)
+	GetWithAge(key string) ([]byte, time.Duration, bool)
+	Delete(key string)
+	Merge(entries map[string]pokecache.CacheEntry) int
+	GetCacheMap() map[string]pokecache.CacheEntry
+	Len() int
+	SizeBytes() int
+	MaxBytes() int
+	Stats() (hits, misses int)
+}
+
+// meteredCache counts writes on the Cacher it wraps (-cache-metrics). Hits and
+// misses aren't counted here: every Cacher already reports them through Stats.
+type meteredCache struct {
+	Cacher
+	writes atomic.Int64
+}
+
+func newMeteredCache(c Cacher) *meteredCache {
+	return &meteredCache{Cacher: c}
+}
+
+func (m *meteredCache) Unwrap() Cacher { return m.Cacher }
+
+func (m *meteredCache) Add(key string, val []byte) {
+	m.writes.Add(1)
+	m.Cacher.Add(key, val)
+}
+
+// findMetered returns the meteredCache somewhere in c's chain of decorators, or nil
+func findMetered(c Cacher) *meteredCache {
+	for c != nil {
+		if m, ok := c.(*meteredCache); ok {
+			return m
+		}
+		u, ok := c.(interface{ Unwrap() Cacher })
+		if !ok {
+			return nil
+		}
+		c = u.Unwrap()
+	}
+	return nil
+}
+
+// loggingCache writes a line to w for every lookup and change on the Cacher it wraps (-log-cache)
+type loggingCache struct {
+	Cacher
+	w io.Writer
+}
+
+func newLoggingCache(c Cacher, w io.Writer) *loggingCache {
+	return &loggingCache{Cacher: c, w: w}
+}
+
+func (l *loggingCache) Unwrap() Cacher { return l.Cacher }
+
+func (l *loggingCache) logLookup(key string, found bool) {
+	if found {
+		fmt.Fprintf(l.w, "cache hit %s\n", key)
+	} else {
+		fmt.Fprintf(l.w, "cache miss %s\n", key)
+	}
+}
+
+func (l *loggingCache) Get(key string) ([]byte, bool) {
+	val, found := l.Cacher.Get(key)
+	l.logLookup(key, found)
+	return val, found
+}
+
+func (l *loggingCache) GetWithAge(key string) ([]byte, time.Duration, bool) {
+	val, age, found := l.Cacher.GetWithAge(key)
+	l.logLookup(key, found)
+	return val, age, found
+}
+
+func (l *loggingCache) Add(key string, val []byte) {
+	fmt.Fprintf(l.w, "cache add %s (%d bytes)\n", key, len(val))
+	l.Cacher.Add(key, val)
+}
+
+func (l *loggingCache) Delete(key string) {
+	fmt.Fprintf(l.w, "cache delete %s\n", key)
+	l.Cacher.Delete(key)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deoreal/pokedexcli/internal/pokecache"
+)
+
+func newTestCacher(t *testing.T) *pokecache.Cache {
+	t.Helper()
+	cache := pokecache.NewCache(time.Hour)
+	t.Cleanup(cache.Stop)
+	return cache
+}
+
+func TestMeteredCache(t *testing.T) {
+	inner := newTestCacher(t)
+	m := newMeteredCache(inner)
+
+	m.Add("a", []byte("alpha"))
+	if val, ok := inner.Get("a"); !ok || string(val) != "alpha" {
+		t.Errorf("expected Add to reach the wrapped cache, got %q, %v", val, ok)
+	}
+	if val, ok := m.Get("a"); !ok || string(val) != "alpha" {
+		t.Errorf("expected Get to delegate, got %q, %v", val, ok)
+	}
+	if _, _, ok := m.GetWithAge("a"); !ok {
+		t.Error("expected GetWithAge to delegate")
+	}
+	m.Get("b")
+	if m.Len() != 1 {
+		t.Errorf("expected Len to delegate, got %d", m.Len())
+	}
+
+	if writes := m.writes.Load(); writes != 1 {
+		t.Errorf("expected 1 write, got %d", writes)
+	}
+	if hits, misses := m.Stats(); hits != 3 || misses != 1 {
+		t.Errorf("expected Stats from the wrapped cache, got %d hits, %d misses", hits, misses)
+	}
+}
+
+func TestLoggingCache(t *testing.T) {
+	inner := newTestCacher(t)
+	var log bytes.Buffer
+	l := newLoggingCache(inner, &log)
+
+	l.Add("a", []byte("alpha"))
+	l.Get("a")
+	l.GetWithAge("b")
+	l.Delete("a")
+	if _, ok := inner.Get("a"); ok {
+		t.Error("expected Delete to reach the wrapped cache")
+	}
+
+	want := "cache add a (5 bytes)\ncache hit a\ncache miss b\ncache delete a\n"
+	if log.String() != want {
+		t.Errorf("expected log\n%s\ngot\n%s", want, log.String())
+	}
+}
+
+func TestCacheStatsMetrics(t *testing.T) {
+	cfg := newTestConfig(t)
+	metered := newMeteredCache(cfg.cache)
+	cfg.cache = newLoggingCache(metered, &bytes.Buffer{})
+	cfg.cache.Add("a", []byte("alpha"))
+	cfg.cache.Get("a")
+
+	out := captureOutput(t, func() {
+		processInput("cache stats", cfg)
+	})
+	if !strings.Contains(out, "Hits: 1, misses: 0, writes: 1") {
+		t.Errorf("expected metrics through the logging decorator, got %q", out)
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PokemonResponse is the subset of the /pokemon/{name} response we decode
+type PokemonResponse struct {
+	ID             int    `json:"id"`
+	Name           string `json:"name"`
+	BaseExperience int    `json:"base_experience"`
+	Height         int    `json:"height"`
+	Weight         int    `json:"weight"`
+	Stats          []struct {
+		BaseStat int `json:"base_stat"`
+		Stat     struct {
+			Name string `json:"name"`
+		} `json:"stat"`
+	} `json:"stats"`
+	Types []struct {
+		Type struct {
+			Name string `json:"name"`
+		} `json:"type"`
+	} `json:"types"`
+}
+
+// toPokemon flattens the API response into the Pokemon we store
+func (r PokemonResponse) toPokemon() Pokemon {
+	stats := make([]Stat, 0, len(r.Stats))
+	for _, s := range r.Stats {
+		stats = append(stats, Stat{
+			Name:  s.Stat.Name,
+			Value: s.BaseStat,
+		})
+	}
+	types := make([]string, 0, len(r.Types))
+	for _, t := range r.Types {
+		types = append(types, t.Type.Name)
+	}
+	return Pokemon{
+		ID:             r.ID,
+		Name:           r.Name,
+		BaseExperience: r.BaseExperience,
+		Height:         r.Height,
+		Weight:         r.Weight,
+		Stats:          stats,
+		Types:          types,
+	}
+}
+
+// maxCatchAttempts caps interactive "Try again?" retries for a single catch command
+const maxCatchAttempts = 10
+
+// catchChance returns the percent chance of catching a Pokémon:
+// base 50%, minus (base_experience / 2)%, min 1%, max 90%
+func catchChance(baseExperience int) int {
+	return clampChance(50 - baseExperience/2)
+}
+
+// clampChance keeps a modified catch chance within the usual 1-90% bounds
+func clampChance(chance int) int {
+	if chance < 1 {
+		chance = 1
+	}
+	if chance > 90 {
+		chance = 90
+	}
+	return chance
+}
+
+// catchSummary formats the greppable one-line result printed with -summary
+func catchSummary(name string, chance, roll int) string {
+	result := "escape"
+	if roll <= chance {
+		result = "success"
+	}
+	return fmt.Sprintf("CATCH name=%s chance=%d roll=%d result=%s", name, chance, roll, result)
+}
+
+func commandCatch(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) == 0 {
+		return userErrorf("You must provide a Pokémon name")
+	}
+
+	rest, minChanceArg, hasMinChance, err := popFlagValue(args[0], "min-chance")
+	if err != nil {
+		return userErrorf("%v", err)
+	}
+	rest, ball, hasBall, err := popFlagValue(rest, "ball")
+	if err != nil {
+		return userErrorf("%v", err)
+	}
+	if !hasBall {
+		ball = defaultBall
+	}
+	if err := validBall(ball); err != nil {
+		return userErrorf("%v", err)
+	}
+	minChance := 0
+	if hasMinChance {
+		minChance, err = strconv.Atoi(minChanceArg)
+		if err != nil || minChance < 0 || minChance > 100 {
+			return userErrorf("--min-chance must be a number between 0 and 100")
+		}
+	}
+	if len(rest) == 0 {
+		return userErrorf("You must provide a Pokémon name")
+	}
+
+	if hasBall && strings.HasPrefix(rest[0], "--") {
+		return userErrorf("--ball only works when catching a single Pokémon by name")
+	}
+	if hasBall && cfg.safari != nil {
+		return userErrorf("Only Safari Balls can be used in the Safari Zone.")
+	}
+	// A batch would carry on with ordinary balls once the last Safari Ball is thrown
+	if cfg.safari != nil && (rest[0] == "--range" || rest[0] == "--area") {
+		return userErrorf("Catch Pokémon one at a time in the Safari Zone.")
+	}
+
+	switch rest[0] {
+	case "--range":
+		return catchRange(ctx, cfg, rest[1:], minChance)
+	case "--area":
+		return catchArea(ctx, cfg, rest[1:], minChance)
+	case "--from":
+		return catchFrom(ctx, cfg, rest[1:], minChance)
+	}
+
+	pokemonName := rest[0]
+	if cfg.offline {
+		return queueCatch(cfg, pokemonName)
+	}
+	pokeResp, err := fetchPokemon(ctx, cfg, pokemonName)
+	if isNotFound(err) {
+		return userErrorf("Could not find Pokémon: %s", pokemonName)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = attemptCatch(ctx, cfg, pokeResp, minChance, 0, ball, cfg.interactive)
+	return err
+}
+
+// catchResult is the outcome of a single attemptCatch call
+type catchResult int
+
+const (
+	catchAlreadyCaught catchResult = iota
+	catchRefused
+	catchCaught
+	catchEscaped
+)
+
+// attemptCatch computes the catch chance for a fetched Pokémon, shifted by
+// adjust percentage points, and throws ball, offering "Try again?" retries
+// when allowRetry is set
+func attemptCatch(ctx context.Context, cfg *config, pokeResp PokemonResponse, minChance, adjust int, ball string, allowRetry bool) (catchResult, error) {
+	// Already caught?
+	if _, ok := cfg.pokedex[pokeResp.Name]; ok {
+		fmt.Printf("%s is already in your Pokedex!\n", pokeResp.Name)
+		return catchAlreadyCaught, nil
+	}
+
+	chance, err := computeChance(ctx, cfg, pokeResp)
+	if err != nil {
+		return catchRefused, err
+	}
+	// Only an adjustment is clamped, so -realistic chances above 90% stand
+	if adjust != 0 {
+		chance = clampChance(chance + adjust)
+	}
+	pokemon := pokeResp.toPokemon()
+	if cfg.synergy && len(cfg.party) > 0 {
+		ok, err := partySynergy(ctx, cfg, pokemon.Types)
+		if err != nil {
+			return catchRefused, err
+		}
+		if ok {
+			chance = clampChance(chance + synergyBonus)
+			flavorf(cfg, "+%d%% party synergy bonus\n", synergyBonus)
+		}
+	}
+	if chance := ballChance(ball, chance); chance < minChance {
+		fmt.Printf("Catch chance for %s is %d%%, below your minimum of %d%%. Not throwing.\n", pokeResp.Name, chance, minChance)
+		return catchRefused, nil
+	}
+
+	markSeen(cfg, pokeResp.Name)
+	for attempt := 1; ; attempt++ {
+		throwChance := chance
+		if bonus := persistenceBonus(cfg, pokemon.Name); bonus > 0 {
+			throwChance = clampChance(chance + bonus)
+			flavorf(cfg, "+%d%% persistence bonus\n", bonus)
+		}
+		throwChance = ballChance(ball, throwChance)
+		safari := cfg.safari != nil
+		if safari {
+			if !useSafariBall(cfg) {
+				return catchRefused, nil
+			}
+			throwChance = safariChance(throwChance)
+		}
+		caught := throwBall(ctx, cfg, pokemon, ball, throwChance)
+		recordAttempt(cfg, pokemon.Name, caught)
+		sessionOver := safari && recordSafariThrow(cfg, pokemon.Name, caught)
+		if caught {
+			return catchCaught, nil
+		}
+		// Only offer a retry to a human at the keyboard, and not forever
+		if sessionOver || !allowRetry || attempt >= maxCatchAttempts || !confirm(cfg, "Try again? (y/N) ") {
+			return catchEscaped, nil
+		}
+	}
+}
+
+// computeChance returns the catch chance for a Pokémon under the current settings
+func computeChance(ctx context.Context, cfg *config, pokeResp PokemonResponse) (int, error) {
+	if cfg.realistic {
+		captureRate, err := fetchCaptureRate(ctx, cfg, pokeResp.Name)
+		if err != nil {
+			return 0, err
+		}
+		return realisticCatchChance(captureRate), nil
+	}
+	return catchChance(pokeResp.BaseExperience), nil
+}
+
+// fetchPokemon fetches and decodes /pokemon/{nameOrID}
+func fetchPokemon(ctx context.Context, cfg *config, nameOrID string) (PokemonResponse, error) {
+	url := fmt.Sprintf("%s/pokemon/%s", cfg.baseURL, neturl.PathEscape(nameOrID))
+	pokeResp, err := getJSON[PokemonResponse](ctx, cfg, url)
+	if err != nil {
+		return pokeResp, fmt.Errorf("failed to fetch Pokémon data: %w", err)
+	}
+	return pokeResp, nil
+}
+
+// throwBall throws ball at p, rolling against chance, reports the outcome, and adds p to the pokedex on success
+func throwBall(ctx context.Context, cfg *config, p Pokemon, ball string, chance int) bool {
+	flavorf(cfg, "Throwing %s at %s...\n", ballName(ball), p.Name)
+	chance = timeThrow(cfg, chance)
+	roll := cfg.rng.Intn(100) + 1 // 1-100
+	caught := roll <= chance
+
+	outcome := "escaped"
+	if caught {
+		outcome = "caught"
+	}
+	cfg.events.emit(Event{Type: eventCatch, Pokemon: p.Name, Chance: chance, Roll: roll, Outcome: outcome})
+	cfg.catchLog.record(p.Name, chance, roll, caught)
+	cfg.counters.catchAttempts.Add(1)
+
+	if caught {
+		if rollCriticalCapture(cfg, p) {
+			announceCriticalCapture(cfg, p)
+		}
+		if cfg.quiet {
+			fmt.Println("caught")
+		} else {
+			fmt.Printf("Congratulations! You caught %s!\n", p.Name)
+		}
+		cfg.counters.catches.Add(1)
+		p.CaughtAt = time.Now()
+		cfg.pokedex[p.Name] = p
+		cfg.lastCaught = p.Name
+		afterCatch(ctx, cfg, p)
+	} else if cfg.quiet {
+		fmt.Println("escaped")
+	} else {
+		fmt.Printf("%s escaped!\n", p.Name)
+	}
+
+	if cfg.summary {
+		fmt.Println(catchSummary(p.Name, chance, roll))
+	}
+	return caught
+}
+
+// confirm prints prompt and reports whether the next input line is a yes
+func confirm(cfg *config, prompt string) bool {
+	if cfg.lines == nil {
+		return false
+	}
+	fmt.Print(prompt)
+	line, ok := <-cfg.lines
+	if !ok {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes"
+}
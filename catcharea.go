@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// catchArea attempts to catch every Pokémon found in a location area. Ones
+// already in the pokedex are skipped unless --include-caught is given.
+func catchArea(ctx context.Context, cfg *config, args []string, minChance int) error {
+	rest, includeCaught := popFlag(args, "include-caught")
+	if len(rest) != 1 {
+		return userErrorf("Usage: catch --area <location-area-name> [--include-caught]")
+	}
+	areaName := rest[0]
+
+	area, err := fetchLocationArea(ctx, cfg, areaName)
+	if isNotFound(err) {
+		return userErrorf("Could not find location area: %s", areaName)
+	}
+	if err != nil {
+		return err
+	}
+	names := encounterNames(area, true)
+	markSeen(cfg, names...)
+
+	targets := names
+	if !includeCaught {
+		targets = nil
+		var owned []string
+		for _, name := range names {
+			if _, ok := cfg.pokedex[name]; ok {
+				owned = append(owned, name)
+			} else {
+				targets = append(targets, name)
+			}
+		}
+		if len(owned) > 0 {
+			fmt.Printf("Skipping already caught: %s\n", strings.Join(owned, ", "))
+		}
+	}
+
+	return runBatch(ctx, cfg, func(ctx context.Context) error {
+		return catchNames(ctx, cfg, areaName, targets, minChance)
+	})
+}
+
+// catchNames is the body of catchArea, stopping early if ctx is cancelled
+func catchNames(ctx context.Context, cfg *config, areaName string, names []string, minChance int) error {
+	var caught, escaped, skipped, failed int
+	for i, name := range names {
+		if ctx.Err() != nil {
+			fmt.Printf("%s, caught %d so far\n", stopReason(ctx), caught)
+			return nil
+		}
+
+		fmt.Printf("[%d/%d] %s\n", i+1, len(names), name)
+		pokeResp, err := fetchPokemon(ctx, cfg, name)
+		if ctx.Err() != nil {
+			continue // reported at the top of the loop
+		}
+		if isNotFound(err) {
+			fmt.Printf("Could not find Pokémon: %s\n", name)
+			failed++
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Error fetching %s: %v\n", name, err)
+			failed++
+			continue
+		}
+
+		result, err := attemptCatch(ctx, cfg, pokeResp, minChance, 0, defaultBall, false)
+		if err != nil {
+			fmt.Printf("Error catching %s: %v\n", name, err)
+			failed++
+			continue
+		}
+		switch result {
+		case catchCaught:
+			caught++
+		case catchEscaped:
+			escaped++
+		default:
+			skipped++
+		}
+	}
+
+	fmt.Printf("Area %s: %d caught, %d escaped, %d skipped, %d failed\n", areaName, caught, escaped, skipped, failed)
+	return nil
+}
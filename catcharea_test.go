@@ -0,0 +1,45 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCatchAreaSkipsCaught(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/location-area/forest": `{"name":"forest","pokemon_encounters":[
+			{"pokemon":{"name":"weedle"}},
+			{"pokemon":{"name":"caterpie"}},
+			{"pokemon":{"name":"pikachu"}}
+		]}`,
+		"/pokemon/caterpie": `{"id":10,"name":"caterpie","base_experience":0}`,
+		"/pokemon/weedle":   `{"id":13,"name":"weedle","base_experience":0}`,
+		"/pokemon/pikachu":  `{"id":25,"name":"pikachu","base_experience":0}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.pokedex["pikachu"] = Pokemon{Name: "pikachu"}
+
+	out := captureOutput(t, func() {
+		processInput("catch --area forest", cfg)
+	})
+	if !strings.Contains(out, "Skipping already caught: pikachu\n") {
+		t.Errorf("expected pikachu to be reported as skipped, got %q", out)
+	}
+	if strings.Contains(out, "already in your Pokedex") || strings.Contains(out, "pikachu...") {
+		t.Errorf("expected no throw at pikachu, got %q", out)
+	}
+	if !strings.Contains(out, "[1/2] caterpie\n") || !strings.Contains(out, "[2/2] weedle\n") {
+		t.Errorf("expected only uncaught Pokémon to be attempted, got %q", out)
+	}
+	if !strings.Contains(out, "Area forest: ") {
+		t.Errorf("expected an area summary, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("catch --area forest --include-caught", cfg)
+	})
+	if !strings.Contains(out, "pikachu is already in your Pokedex!") || strings.Contains(out, "Skipping already caught") {
+		t.Errorf("expected --include-caught to attempt owned Pokémon, got %q", out)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import "context"
+
+// Encounter weighting for catch --from: a Pokémon you rarely run into is a
+// little harder to catch. Below an encounter chance of 30% (the Common rarity
+// threshold), every 5 points cost 1% catch chance, so the penalty is at most
+// 5%. Pokémon without encounter chance data aren't adjusted.
+const encounterPenaltyStep = 5
+
+// encounterPenalty returns how many percentage points to take off the catch
+// chance for a Pokémon with the given encounter chance in an area
+func encounterPenalty(encounterChance int) int {
+	if encounterChance <= 0 || encounterChance >= commonMinChance {
+		return 0
+	}
+	return (commonMinChance - encounterChance) / encounterPenaltyStep
+}
+
+// catchFrom tries to catch a Pokémon as encountered in a location area, with
+// its catch chance lowered if it is a rare encounter there
+func catchFrom(ctx context.Context, cfg *config, args []string, minChance int) error {
+	if len(args) != 2 {
+		return userErrorf("Usage: catch --from <location-area-name> <pokemon-name>")
+	}
+	areaName, pokemonName := args[0], args[1]
+
+	area, err := fetchLocationArea(ctx, cfg, areaName)
+	if isNotFound(err) {
+		return userErrorf("Could not find location area: %s", areaName)
+	}
+	if err != nil {
+		return err
+	}
+	encounterChance, ok := encounterMaxChances(area)[pokemonName]
+	if !ok {
+		return userErrorf("%s can't be found in %s", pokemonName, areaName)
+	}
+	pokeResp, err := fetchPokemon(ctx, cfg, pokemonName)
+	if isNotFound(err) {
+		return userErrorf("Could not find Pokémon: %s", pokemonName)
+	}
+	if err != nil {
+		return err
+	}
+
+	penalty := encounterPenalty(encounterChance)
+	if penalty > 0 {
+		flavorf(cfg, "-%d%% rare encounter (%d%% in %s)\n", penalty, encounterChance, areaName)
+	}
+	_, err = attemptCatch(ctx, cfg, pokeResp, minChance, -penalty, defaultBall, cfg.interactive)
+	return err
+}
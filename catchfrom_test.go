@@ -0,0 +1,52 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEncounterPenalty(t *testing.T) {
+	cases := map[int]int{0: 0, 1: 5, 5: 5, 10: 4, 25: 1, 29: 0, 30: 0, 60: 0}
+	for chance, want := range cases {
+		if got := encounterPenalty(chance); got != want {
+			t.Errorf("encounterPenalty(%d) = %d, expected %d", chance, got, want)
+		}
+	}
+}
+
+func TestCatchFrom(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/location-area/viridian-forest-area": `{"pokemon_encounters":[
+			{"pokemon":{"name":"pikachu"},"version_details":[{"max_chance":5}]},
+			{"pokemon":{"name":"caterpie"},"version_details":[{"max_chance":40}]}
+		]}`,
+		"/pokemon/pikachu":  `{"name":"pikachu","base_experience":40}`,
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":40}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.summary = true
+	run := func(input string) string {
+		return captureOutput(t, func() {
+			processInput(input, cfg)
+		})
+	}
+
+	out := run("catch --from viridian-forest-area pikachu")
+	if !strings.Contains(out, "-5% rare encounter (5% in viridian-forest-area)") {
+		t.Errorf("expected a rare encounter note, got %q", out)
+	}
+	if !strings.Contains(out, "CATCH name=pikachu chance=25 ") {
+		t.Errorf("expected the 30%% chance lowered to 25%%, got %q", out)
+	}
+
+	out = run("catch --from viridian-forest-area caterpie")
+	if strings.Contains(out, "rare encounter") || !strings.Contains(out, "CATCH name=caterpie chance=30 ") {
+		t.Errorf("expected a common encounter to keep its chance, got %q", out)
+	}
+
+	out = run("catch --from viridian-forest-area onix")
+	if !strings.Contains(out, "onix can't be found in viridian-forest-area") {
+		t.Errorf("expected a rejection for a Pokémon not in the area, got %q", out)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// dataDir returns ~/.pokedexcli, where the CLI keeps its files
+func dataDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("error finding home directory: %w", err)
+	}
+	return filepath.Join(home, ".pokedexcli"), nil
+}
+
+// catchLog appends one tab-separated line per catch attempt:
+//
+//	<RFC3339 timestamp>\t<pokemon>\t<chance>\t<roll>\t<caught|escaped>
+//
+// A nil *catchLog discards attempts.
+type catchLog struct {
+	mu   sync.Mutex
+	file *os.File
+	now  func() time.Time
+}
+
+// openCatchLog opens path for appending, creating it and its directory if needed
+func openCatchLog(path string) (*catchLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("error creating catch log directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening catch log: %w", err)
+	}
+	return &catchLog{file: f, now: time.Now}, nil
+}
+
+// record appends a single attempt
+func (l *catchLog) record(name string, chance, roll int, caught bool) {
+	if l == nil {
+		return
+	}
+	outcome := "escaped"
+	if caught {
+		outcome = "caught"
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err := fmt.Fprintf(l.file, "%s\t%s\t%d\t%d\t%s\n", l.now().Format(time.RFC3339), name, chance, roll, outcome)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing catch log: %v\n", err)
+	}
+}
+
+// Close closes the underlying file
+func (l *catchLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCatchLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "catches.log")
+	log, err := openCatchLog(path)
+	if err != nil {
+		t.Fatalf("openCatchLog: %v", err)
+	}
+	log.now = func() time.Time { return time.Date(2024, time.March, 2, 15, 4, 5, 0, time.UTC) }
+
+	cfg := newTestConfig(t)
+	cfg.catchLog = log
+	captureOutput(t, func() {
+		throwBall(context.Background(), cfg, Pokemon{Name: "pidgey"}, defaultBall, 100) // always caught
+		throwBall(context.Background(), cfg, Pokemon{Name: "mewtwo"}, defaultBall, 0)   // always escapes
+	})
+	if err := log.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got %d: %q", len(lines), data)
+	}
+
+	for i, want := range []struct{ name, chance, outcome string }{
+		{"pidgey", "100", "caught"},
+		{"mewtwo", "0", "escaped"},
+	} {
+		fields := strings.Split(lines[i], "\t")
+		if len(fields) != 5 {
+			t.Fatalf("line %d: expected 5 tab-separated fields, got %q", i, lines[i])
+		}
+		if fields[0] != "2024-03-02T15:04:05Z" || fields[1] != want.name || fields[2] != want.chance || fields[4] != want.outcome {
+			t.Errorf("line %d: unexpected fields %q", i, fields)
+		}
+	}
+}
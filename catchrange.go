@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// maxNationalDexID is the highest national Pokédex number PokeAPI serves
+const maxNationalDexID = 1025
+
+// catchRange attempts to catch every national-dex ID in [start, end], skipping ones already caught
+func catchRange(ctx context.Context, cfg *config, args []string, minChance int) error {
+	if len(args) != 2 {
+		return userErrorf("Usage: catch --range <start> <end>")
+	}
+	start, errStart := strconv.Atoi(args[0])
+	end, errEnd := strconv.Atoi(args[1])
+	if errStart != nil || errEnd != nil || start < 1 || end > maxNationalDexID || start > end {
+		return userErrorf("Range must satisfy 1 <= start <= end <= %d", maxNationalDexID)
+	}
+
+	caughtIDs := make(map[int]bool, len(cfg.pokedex))
+	for _, p := range cfg.pokedex {
+		if p.ID != 0 {
+			caughtIDs[p.ID] = true
+		}
+	}
+
+	return runBatch(ctx, cfg, func(ctx context.Context) error {
+		return catchIDs(ctx, cfg, start, end, caughtIDs, minChance)
+	})
+}
+
+// catchIDs is the body of catchRange, stopping early if ctx is cancelled
+func catchIDs(ctx context.Context, cfg *config, start, end int, caughtIDs map[int]bool, minChance int) error {
+	var caught, escaped, skipped, failed int
+	total := end - start + 1
+	for id := start; id <= end; id++ {
+		if ctx.Err() != nil {
+			fmt.Printf("%s, caught %d so far\n", stopReason(ctx), caught)
+			return nil
+		}
+
+		fmt.Printf("[%d/%d] #%d\n", id-start+1, total, id)
+		if caughtIDs[id] {
+			skipped++
+			continue
+		}
+
+		pokeResp, err := fetchPokemon(ctx, cfg, strconv.Itoa(id))
+		if ctx.Err() != nil {
+			continue // reported at the top of the loop
+		}
+		if isNotFound(err) {
+			fmt.Printf("Could not find Pokémon #%d\n", id)
+			failed++
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Error fetching #%d: %v\n", id, err)
+			failed++
+			continue
+		}
+
+		result, err := attemptCatch(ctx, cfg, pokeResp, minChance, 0, defaultBall, false)
+		if err != nil {
+			fmt.Printf("Error catching #%d: %v\n", id, err)
+			failed++
+			continue
+		}
+		switch result {
+		case catchCaught:
+			caught++
+		case catchEscaped:
+			escaped++
+		default:
+			skipped++
+		}
+	}
+
+	fmt.Printf("Range %d-%d: %d caught, %d escaped, %d skipped, %d failed\n", start, end, caught, escaped, skipped, failed)
+	return nil
+}
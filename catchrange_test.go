@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCatchRange(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/1": `{"id":1,"name":"bulbasaur","base_experience":0}`,
+		"/pokemon/2": `{"id":2,"name":"ivysaur","base_experience":0}`,
+		"/pokemon/3": `{"id":3,"name":"venusaur","base_experience":0}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.pokedex["ivysaur"] = Pokemon{ID: 2, Name: "ivysaur"}
+
+	// Replay the two rolls the range will make from the same seed
+	rng := rand.New(rand.NewSource(1))
+	chance := catchChance(0)
+	wantCaught := map[string]bool{}
+	caught := 0
+	for _, name := range []string{"bulbasaur", "venusaur"} {
+		if rng.Intn(100)+1 <= chance {
+			wantCaught[name] = true
+			caught++
+		}
+	}
+
+	out := captureOutput(t, func() {
+		processInput("catch --range 1 4", cfg)
+	})
+
+	want := fmt.Sprintf("Range 1-4: %d caught, %d escaped, 1 skipped, 1 failed", caught, 2-caught)
+	if !strings.Contains(out, want) {
+		t.Errorf("expected summary %q, got %q", want, out)
+	}
+	for _, name := range []string{"bulbasaur", "venusaur"} {
+		if _, ok := cfg.pokedex[name]; ok != wantCaught[name] {
+			t.Errorf("%s in pokedex = %v, expected %v", name, ok, wantCaught[name])
+		}
+	}
+	if strings.Contains(out, "ivysaur") {
+		t.Errorf("already-caught ID should be skipped without fetching, got %q", out)
+	}
+}
+
+func TestCatchRangeValidation(t *testing.T) {
+	cfg := newTestConfig(t)
+	for _, input := range []string{"catch --range 0 5", "catch --range 5 1", "catch --range 1 2000", "catch --range a b"} {
+		out := captureOutput(t, func() {
+			processInput(input, cfg)
+		})
+		if !strings.Contains(out, "Range must satisfy") {
+			t.Errorf("%q: expected validation message, got %q", input, out)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+)
+
+// commandCompare prints two caught Pokémon side by side, naming the higher of each row
+func commandCompare(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) != 2 {
+		return userErrorf("Usage: compare <pokemon> <pokemon>")
+	}
+	nameA, nameB := args[0][0], args[0][1]
+
+	var errs []error
+	a, okA := cfg.pokedex[nameA]
+	if !okA {
+		errs = append(errs, userErrorf("You have not caught %s.", nameA))
+	}
+	b, okB := cfg.pokedex[nameB]
+	if !okB {
+		errs = append(errs, userErrorf("You have not caught %s.", nameB))
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return printComparison(os.Stdout, a, b)
+}
+
+// printComparison writes a row per shared attribute: base experience, height,
+// weight, then each stat in a's order followed by any only b has
+func printComparison(w io.Writer, a, b Pokemon) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "\t%s\t%s\tHigher\n", a.Name, b.Name)
+	row := func(label string, valA, valB int, hasA, hasB bool) {
+		higher := "-"
+		switch {
+		case hasA && hasB && valA > valB:
+			higher = a.Name
+		case hasA && hasB && valB > valA:
+			higher = b.Name
+		case hasA && hasB:
+			higher = "tie"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", label, statCell(valA, hasA), statCell(valB, hasB), higher)
+	}
+	row("Base experience", a.BaseExperience, b.BaseExperience, true, true)
+	row("Height", a.Height, b.Height, true, true)
+	row("Weight", a.Weight, b.Weight, true, true)
+
+	statsB := make(map[string]int, len(b.Stats))
+	for _, s := range b.Stats {
+		statsB[s.Name] = s.Value
+	}
+	seen := make(map[string]bool, len(a.Stats))
+	for _, s := range a.Stats {
+		valB, hasB := statsB[s.Name]
+		row(s.Name, s.Value, valB, true, hasB)
+		seen[s.Name] = true
+	}
+	for _, s := range b.Stats {
+		if !seen[s.Name] {
+			row(s.Name, 0, s.Value, false, true)
+		}
+	}
+	return tw.Flush()
+}
+
+// statCell formats a comparison value, or "-" for a stat the Pokémon lacks
+func statCell(value int, ok bool) string {
+	if !ok {
+		return "-"
+	}
+	return strconv.Itoa(value)
+}
+
+// commandCompareAreas prints the Pokémon unique to each of two location areas and those they share
+func commandCompareAreas(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) != 2 {
+		return userErrorf("Usage: compare-areas <area> <area>")
+	}
+	nameA, nameB := args[0][0], args[0][1]
+
+	areaA, errA := fetchLocationArea(ctx, cfg, nameA)
+	areaB, errB := fetchLocationArea(ctx, cfg, nameB)
+	var errs []error
+	for _, f := range []struct {
+		name string
+		err  error
+	}{{nameA, errA}, {nameB, errB}} {
+		if isNotFound(f.err) {
+			errs = append(errs, userErrorf("Could not find location area: %s", f.name))
+		} else if f.err != nil {
+			errs = append(errs, f.err)
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	onlyA, onlyB, shared := diffNames(encounterNames(areaA, true), encounterNames(areaB, true))
+	markSeen(cfg, onlyA...)
+	markSeen(cfg, onlyB...)
+	markSeen(cfg, shared...)
+
+	printNameSection("Only in "+nameA, onlyA)
+	printNameSection("Only in "+nameB, onlyB)
+	printNameSection("In both", shared)
+	return nil
+}
+
+// diffNames splits two sorted, deduplicated lists into the names only in a,
+// only in b, and in both, each still sorted
+func diffNames(a, b []string) (onlyA, onlyB, shared []string) {
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			shared = append(shared, a[i])
+			i++
+			j++
+		case a[i] < b[j]:
+			onlyA = append(onlyA, a[i])
+			i++
+		default:
+			onlyB = append(onlyB, b[j])
+			j++
+		}
+	}
+	onlyA = append(onlyA, a[i:]...)
+	onlyB = append(onlyB, b[j:]...)
+	return onlyA, onlyB, shared
+}
+
+// printNameSection prints a titled list of names, or "none" if it is empty
+func printNameSection(title string, names []string) {
+	fmt.Printf("%s (%d):\n", title, len(names))
+	if len(names) == 0 {
+		fmt.Println(" - none")
+		return
+	}
+	for _, name := range names {
+		fmt.Printf(" - %s\n", name)
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCompareAreas(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/location-area/cave": `{"name":"cave","pokemon_encounters":[
+			{"pokemon":{"name":"zubat"}},
+			{"pokemon":{"name":"geodude"}},
+			{"pokemon":{"name":"zubat"}},
+			{"pokemon":{"name":"onix"}}
+		]}`,
+		"/location-area/tunnel": `{"name":"tunnel","pokemon_encounters":[
+			{"pokemon":{"name":"zubat"}},
+			{"pokemon":{"name":"diglett"}},
+			{"pokemon":{"name":"geodude"}}
+		]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	out := captureOutput(t, func() {
+		processInput("compare-areas cave tunnel", cfg)
+	})
+	want := "Only in cave (1):\n - onix\n" +
+		"Only in tunnel (1):\n - diglett\n" +
+		"In both (2):\n - geodude\n - zubat\n"
+	if out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("compare-areas nowhere tunnel", cfg)
+	})
+	if !strings.Contains(out, "Could not find location area: nowhere") || strings.Contains(out, "In both") {
+		t.Errorf("expected an error for the invalid side only, got %q", out)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.pokedex["pikachu"] = Pokemon{
+		Name: "pikachu", BaseExperience: 112, Height: 4, Weight: 60,
+		Stats: []Stat{{Name: "hp", Value: 35}, {Name: "speed", Value: 90}},
+	}
+	cfg.pokedex["raichu"] = Pokemon{
+		Name: "raichu", BaseExperience: 218, Height: 8, Weight: 300,
+		Stats: []Stat{{Name: "hp", Value: 60}, {Name: "speed", Value: 90}},
+	}
+
+	out := captureOutput(t, func() {
+		processInput("compare pikachu raichu", cfg)
+	})
+	want := "                 pikachu  raichu  Higher\n" +
+		"Base experience  112      218     raichu\n" +
+		"Height           4        8       raichu\n" +
+		"Weight           60       300     raichu\n" +
+		"hp               35       60      raichu\n" +
+		"speed            90       90      tie\n"
+	if out != want {
+		t.Errorf("expected\n%s\ngot\n%s", want, out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("compare pikachu mewtwo", cfg)
+	})
+	if out != "You have not caught mewtwo.\n" {
+		t.Errorf("expected only the missing Pokémon reported, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("compare pikachu", cfg)
+	})
+	if !strings.Contains(out, "Usage: compare <pokemon> <pokemon>") {
+		t.Errorf("expected usage for one name, got %q", out)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// completeCommand returns the registered command names starting with prefix, sorted
+func completeCommand(prefix string) []string {
+	var matches []string
+	for name := range Commands {
+		if strings.HasPrefix(name, prefix) {
+			matches = append(matches, name)
+		}
+	}
+	sort.Strings(matches)
+	return matches
+}
+
+// expandCompletion handles a line whose first word was ended with TAB. The
+// terminal is line-buffered, so the tab only reaches us once Enter is
+// pressed: a unique match replaces the partial word and the completed line
+// runs only once the user confirms it, since they haven't seen it yet. Piped
+// input can't confirm, so there the completion is only printed. Otherwise
+// the candidates are printed and ok is false. Lines without a tab come back
+// trimmed.
+func expandCompletion(cfg *config, line string) (string, bool) {
+	before, after, found := strings.Cut(line, "\t")
+	words := strings.Fields(before)
+	if !found || len(words) != 1 {
+		return strings.TrimSpace(line), true
+	}
+
+	matches := completeCommand(words[0])
+	switch len(matches) {
+	case 0:
+		fmt.Printf("No commands start with %q\n", words[0])
+		return "", false
+	case 1:
+		completed := strings.TrimSpace(matches[0] + " " + strings.TrimSpace(after))
+		fmt.Printf("Completed to: %s\n", completed)
+		if !cfg.interactive || !confirm(cfg, "Run it? (y/N) ") {
+			return "", false
+		}
+		return completed, true
+	default:
+		fmt.Println(strings.Join(matches, "  "))
+		return "", false
+	}
+}
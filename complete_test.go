@@ -0,0 +1,77 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompleteCommand(t *testing.T) {
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"x", nil},
+		{"m", []string{"map", "mapb"}},
+		{"ma", []string{"map", "mapb"}},
+		{"mapb", []string{"mapb"}},
+		{"expl", []string{"explore"}},
+	}
+	for _, tt := range tests {
+		if got := completeCommand(tt.prefix); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("completeCommand(%q) = %v, want %v", tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestExpandCompletion(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.interactive = true
+	lines := make(chan string, 1)
+	cfg.lines = lines
+
+	var line string
+	var ok bool
+	lines <- "y"
+	out := captureOutput(t, func() {
+		line, ok = expandCompletion(cfg, "expl\tpastoria-city-area")
+	})
+	if !ok || line != "explore pastoria-city-area" {
+		t.Errorf("expected the unique match completed, got %q (ok=%v)", line, ok)
+	}
+	if !strings.Contains(out, "Completed to: explore pastoria-city-area\nRun it? (y/N) ") {
+		t.Errorf("expected the completed line echoed before confirming, got %q", out)
+	}
+
+	lines <- ""
+	captureOutput(t, func() {
+		line, ok = expandCompletion(cfg, "rel\tpikachu")
+	})
+	if ok {
+		t.Errorf("expected an unconfirmed completion not to run, got %q", line)
+	}
+
+	out = captureOutput(t, func() {
+		line, ok = expandCompletion(cfg, "ma\t")
+	})
+	if ok || !strings.Contains(out, "map  mapb") {
+		t.Errorf("expected candidates listed, got %q (ok=%v)", out, ok)
+	}
+
+	if line, ok = expandCompletion(cfg, "  map  "); !ok || line != "map" {
+		t.Errorf("expected a line without a tab passed through, got %q (ok=%v)", line, ok)
+	}
+}
+
+func TestCompletionInScriptDoesNotEatNextLine(t *testing.T) {
+	cfg := newTestConfig(t)
+	out := captureOutput(t, func() {
+		runREPL(strings.NewReader("his\t\nhelp exit\n"), cfg)
+	})
+	if !strings.Contains(out, "Completed to: history") || strings.Contains(out, "Run it?") {
+		t.Errorf("expected the completion printed without a prompt, got %q", out)
+	}
+	if !strings.Contains(out, "exit: Exit the Pokedex") {
+		t.Errorf("expected the next script line to run, got %q", out)
+	}
+}
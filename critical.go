@@ -0,0 +1,35 @@
+package main
+
+// criticalCaptureChance returns the percent chance that a successful catch is
+// a critical capture. Like the games it grows with dex completion (none until
+// 30 caught, +1% per further 30), and rarer Pokémon add +1% per 100 base
+// experience. The result is capped at 20%.
+func criticalCaptureChance(caught, baseExperience int) int {
+	chance := 0
+	if caught >= 30 {
+		chance = caught / 30
+	}
+	if chance > 0 {
+		chance += baseExperience / 100
+	}
+	if chance > 20 {
+		chance = 20
+	}
+	return chance
+}
+
+// rollCriticalCapture decides whether a successful catch was critical. It only
+// draws from cfg.rng when a critical capture is possible, so ordinary catch
+// sequences stay reproducible.
+func rollCriticalCapture(cfg *config, p Pokemon) bool {
+	chance := criticalCaptureChance(len(cfg.pokedex), p.BaseExperience)
+	if chance == 0 {
+		return false
+	}
+	return cfg.rng.Intn(100) < chance
+}
+
+// announceCriticalCapture prints the critical capture flair
+func announceCriticalCapture(cfg *config, p Pokemon) {
+	flavorf(cfg, "Critical capture! The ball shook just once around %s...\n", p.Name)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestCriticalCaptureChance(t *testing.T) {
+	cases := []struct {
+		caught, baseExperience, expected int
+	}{
+		{caught: 0, baseExperience: 300, expected: 0},
+		{caught: 29, baseExperience: 300, expected: 0},
+		{caught: 30, baseExperience: 0, expected: 1},
+		{caught: 300, baseExperience: 0, expected: 10},
+		{caught: 300, baseExperience: 250, expected: 12},
+		{caught: 1025, baseExperience: 340, expected: 20},
+	}
+	for _, c := range cases {
+		if actual := criticalCaptureChance(c.caught, c.baseExperience); actual != c.expected {
+			t.Errorf("criticalCaptureChance(%d, %d) = %d, expected %d", c.caught, c.baseExperience, actual, c.expected)
+		}
+	}
+}
+
+func TestCriticalCaptureSeeded(t *testing.T) {
+	// With 300 caught the crit chance is 10%. Each seed's second draw is the
+	// crit roll: seed 6 draws 3 (critical), seed 1 draws 87 (not critical).
+	cases := []struct {
+		seed     int64
+		critical bool
+	}{
+		{seed: 6, critical: true},
+		{seed: 1, critical: false},
+	}
+
+	for _, c := range cases {
+		cfg := newTestConfig(t)
+		cfg.rng = rand.New(rand.NewSource(c.seed))
+		for i := 0; i < 300; i++ {
+			name := fmt.Sprintf("filler-%d", i)
+			cfg.pokedex[name] = Pokemon{Name: name}
+		}
+
+		out := captureOutput(t, func() {
+			throwBall(context.Background(), cfg, Pokemon{Name: "snorlax"}, defaultBall, 100)
+		})
+		if got := strings.Contains(out, "Critical capture!"); got != c.critical {
+			t.Errorf("seed %d: critical = %v, expected %v (output %q)", c.seed, got, c.critical, out)
+		}
+		if _, ok := cfg.pokedex["snorlax"]; !ok {
+			t.Errorf("seed %d: expected snorlax to be caught", c.seed)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/deoreal/pokedexcli/internal/pokecache"
+)
+
+// maxDecodedEntries bounds the decoded cache; when full an arbitrary entry is dropped
+const maxDecodedEntries = 256
+
+// decodedCache is a Cacher decorator that also remembers the values each
+// cached body decoded to, so a byte-cache hit can skip json.Unmarshal. Adding,
+// deleting or merging a key through it drops that key's decoded values, and
+// each entry is tied to the exact body slice it was decoded from, so a body
+// the wrapped cache replaced or reaped on its own is never matched either.
+// Values are shared between callers and must not be modified.
+type decodedCache struct {
+	Cacher
+	mu      sync.Mutex
+	entries map[string]decodedEntry
+}
+
+// decodedEntry holds one body's decoded values, by Go type name
+type decodedEntry struct {
+	body []byte
+	vals map[string]any
+}
+
+func newDecodedCache(c Cacher) *decodedCache {
+	return &decodedCache{Cacher: c, entries: make(map[string]decodedEntry)}
+}
+
+func (d *decodedCache) Unwrap() Cacher { return d.Cacher }
+
+func (d *decodedCache) Add(key string, val []byte) {
+	d.forget(key)
+	d.Cacher.Add(key, val)
+}
+
+func (d *decodedCache) Delete(key string) {
+	d.forget(key)
+	d.Cacher.Delete(key)
+}
+
+func (d *decodedCache) Merge(entries map[string]pokecache.CacheEntry) int {
+	for key := range entries {
+		d.forget(key)
+	}
+	return d.Cacher.Merge(entries)
+}
+
+// forget drops every value decoded from key's body
+func (d *decodedCache) forget(key string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.entries, key)
+}
+
+// decoded returns the typeName value decoded from body under key, if body is
+// the same bytes it was decoded from. A nil *decodedCache caches nothing.
+func (d *decodedCache) decoded(key, typeName string, body []byte) (any, bool) {
+	if d == nil || len(body) == 0 {
+		return nil, false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.entries[key]
+	if !ok || len(entry.body) != len(body) || &entry.body[0] != &body[0] {
+		return nil, false
+	}
+	val, ok := entry.vals[typeName]
+	return val, ok
+}
+
+// storeDecoded records that body, cached under key, decoded to val of typeName
+func (d *decodedCache) storeDecoded(key, typeName string, body []byte, val any) {
+	if d == nil || len(body) == 0 {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entry, ok := d.entries[key]
+	if !ok || len(entry.body) != len(body) || &entry.body[0] != &body[0] {
+		if !ok && len(d.entries) >= maxDecodedEntries {
+			for old := range d.entries {
+				delete(d.entries, old)
+				break
+			}
+		}
+		entry = decodedEntry{body: body, vals: make(map[string]any)}
+		d.entries[key] = entry
+	}
+	entry.vals[typeName] = val
+}
+
+// findDecoded returns the decodedCache somewhere in c's chain of decorators, or nil
+func findDecoded(c Cacher) *decodedCache {
+	for c != nil {
+		if d, ok := c.(*decodedCache); ok {
+			return d
+		}
+		u, ok := c.(interface{ Unwrap() Cacher })
+		if !ok {
+			return nil
+		}
+		c = u.Unwrap()
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deoreal/pokedexcli/internal/pokecache"
+)
+
+const pikachuJSON = `{"id":25,"name":"pikachu","base_experience":112,"height":4,"weight":60,
+	"stats":[{"base_stat":35,"stat":{"name":"hp"}},{"base_stat":90,"stat":{"name":"speed"}}],
+	"types":[{"type":{"name":"electric"}}]}`
+
+func TestDecodedCacheMatchesFreshDecode(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pikachu":      pikachuJSON,
+		"/location-area/meadow": `{"name":"meadow","pokemon_encounters":[{"pokemon":{"name":"pidgey"}}]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	decoded := newDecodedCache(cfg.cache)
+	cfg.cache = decoded
+
+	fresh, err := fetchPokemon(context.Background(), cfg, "pikachu")
+	if err != nil {
+		t.Fatalf("fetchPokemon: %v", err)
+	}
+	cached, err := fetchPokemon(context.Background(), cfg, "pikachu")
+	if err != nil {
+		t.Fatalf("fetchPokemon: %v", err)
+	}
+	if !reflect.DeepEqual(fresh, cached) {
+		t.Errorf("decoded-cache hit %+v differs from fresh decode %+v", cached, fresh)
+	}
+	if len(decoded.entries) != 1 {
+		t.Errorf("expected one decoded entry, got %d", len(decoded.entries))
+	}
+
+	area, _ := fetchLocationArea(context.Background(), cfg, "meadow")
+	areaCached, _ := fetchLocationArea(context.Background(), cfg, "meadow")
+	if !reflect.DeepEqual(area, areaCached) || len(area.PokemonEncounters) != 1 {
+		t.Errorf("decoded-cache hit %+v differs from fresh decode %+v", areaCached, area)
+	}
+
+	// Replacing the cached bytes invalidates the decoded value
+	cfg.cache.Add(srv.URL+"/pokemon/pikachu", []byte(`{"name":"raichu"}`))
+	changed, err := fetchPokemon(context.Background(), cfg, "pikachu")
+	if err != nil || changed.Name != "raichu" {
+		t.Errorf("expected the new cached body to be decoded, got %+v, %v", changed, err)
+	}
+}
+
+func TestDecodedCacheBehindDecorators(t *testing.T) {
+	srv := newTestServer(t, map[string]string{"/pokemon/pikachu": pikachuJSON})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	decoded := newDecodedCache(cfg.cache)
+	var log strings.Builder
+	cfg.cache = newLoggingCache(newMeteredCache(decoded), &log)
+
+	for i := 0; i < 2; i++ {
+		if _, err := fetchPokemon(context.Background(), cfg, "pikachu"); err != nil {
+			t.Fatalf("fetchPokemon: %v", err)
+		}
+	}
+	if findDecoded(cfg.cache) != decoded {
+		t.Fatal("expected findDecoded to unwrap to the decoded cache")
+	}
+	if len(decoded.entries) != 1 {
+		t.Errorf("expected one decoded entry, got %d", len(decoded.entries))
+	}
+	if hits, _ := cfg.cache.Stats(); hits != 1 {
+		t.Errorf("expected the decoded hit to be counted, got %d hits", hits)
+	}
+	if !strings.Contains(log.String(), "cache hit "+srv.URL+"/pokemon/pikachu") {
+		t.Errorf("expected the logging cache to see the hit, got %q", log.String())
+	}
+
+	// Deleting through the outer decorators drops the decoded value too
+	cfg.cache.Delete(srv.URL + "/pokemon/pikachu")
+	if len(decoded.entries) != 0 {
+		t.Errorf("expected Delete to drop the decoded entry, got %d", len(decoded.entries))
+	}
+}
+
+func benchmarkGetJSON(b *testing.B, decoded bool) {
+	cache := pokecache.NewCache(time.Hour)
+	b.Cleanup(cache.Stop)
+	cfg := &config{baseURL: defaultBaseURL, cache: cache}
+	if decoded {
+		cfg.cache = newDecodedCache(cache)
+	}
+	url := defaultBaseURL + "/pokemon/pikachu"
+	cache.Add(url, []byte(pikachuJSON))
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := getJSON[PokemonResponse](context.Background(), cfg, url); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetJSONUnmarshal(b *testing.B) {
+	benchmarkGetJSON(b, false)
+}
+
+func BenchmarkGetJSONDecodedCache(b *testing.B) {
+	benchmarkGetJSON(b, true)
+}
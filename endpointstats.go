@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	neturl "net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// endpointStat aggregates the network requests made to one endpoint pattern
+type endpointStat struct {
+	Pattern  string
+	Requests int
+	Total    time.Duration
+}
+
+// Average returns the mean latency of the requests
+func (s endpointStat) Average() time.Duration {
+	if s.Requests == 0 {
+		return 0
+	}
+	return s.Total / time.Duration(s.Requests)
+}
+
+// endpointStats counts network requests and their latency per endpoint
+// pattern. It is safe for concurrent use, and a nil *endpointStats records nothing.
+type endpointStats struct {
+	mu        sync.Mutex
+	byPattern map[string]*endpointStat
+}
+
+func newEndpointStats() *endpointStats {
+	return &endpointStats{byPattern: make(map[string]*endpointStat)}
+}
+
+// record adds one request to pattern's totals
+func (e *endpointStats) record(pattern string, latency time.Duration) {
+	if e == nil {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	stat, ok := e.byPattern[pattern]
+	if !ok {
+		stat = &endpointStat{Pattern: pattern}
+		e.byPattern[pattern] = stat
+	}
+	stat.Requests++
+	stat.Total += latency
+}
+
+// snapshot returns a copy of the stats, busiest pattern first
+func (e *endpointStats) snapshot() []endpointStat {
+	if e == nil {
+		return nil
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	stats := make([]endpointStat, 0, len(e.byPattern))
+	for _, stat := range e.byPattern {
+		stats = append(stats, *stat)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Requests != stats[j].Requests {
+			return stats[i].Requests > stats[j].Requests
+		}
+		return stats[i].Pattern < stats[j].Pattern
+	})
+	return stats
+}
+
+// endpointPattern normalizes a request URL to its endpoint, dropping the
+// query and replacing the resource name or ID, e.g. ".../pokemon/pikachu/encounters"
+// becomes "/pokemon/{name}/encounters"
+func endpointPattern(baseURL, url string) string {
+	path := strings.TrimPrefix(url, baseURL)
+	if u, err := neturl.Parse(path); err == nil {
+		path = u.Path
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 1 && segments[1] != "" {
+		segments[1] = "{name}"
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// writeEndpointStats prints stats as an aligned table
+func writeEndpointStats(w io.Writer, stats []endpointStat) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Endpoint\tRequests\tAvgLatency")
+	for _, s := range stats {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", s.Pattern, s.Requests, s.Average().Round(time.Millisecond))
+	}
+	return tw.Flush()
+}
+
+// commandEndpointStats shows how many network requests each endpoint received this session
+func commandEndpointStats(ctx context.Context, cfg *config, args ...[]string) error {
+	stats := cfg.endpoints.snapshot()
+	if len(stats) == 0 {
+		fmt.Println("No network requests made yet")
+		return nil
+	}
+	return writeEndpointStats(os.Stdout, stats)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEndpointStats(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pikachu":   `{"name":"pikachu"}`,
+		"/pokemon/bulbasaur": `{"name":"bulbasaur"}`,
+		"/location-area":     `{"count":0,"next":null,"previous":null,"results":[]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.endpoints = newEndpointStats()
+
+	for _, url := range []string{
+		srv.URL + "/pokemon/pikachu",
+		srv.URL + "/pokemon/bulbasaur",
+		srv.URL + "/pokemon/pikachu", // cache hit, not counted
+		srv.URL + "/pokemon/missingno",
+		srv.URL + "/location-area?offset=20&limit=20",
+	} {
+		makeRequest(context.Background(), cfg, url)
+	}
+
+	stats := cfg.endpoints.snapshot()
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 endpoint patterns, got %+v", stats)
+	}
+	if stats[0].Pattern != "/pokemon/{name}" || stats[0].Requests != 3 {
+		t.Errorf("expected 3 requests to /pokemon/{name} first, got %+v", stats[0])
+	}
+	if stats[1].Pattern != "/location-area" || stats[1].Requests != 1 {
+		t.Errorf("expected 1 request to /location-area, got %+v", stats[1])
+	}
+
+	out := captureOutput(t, func() {
+		processInput("endpoint-stats", cfg)
+	})
+	if !strings.Contains(out, "/pokemon/{name}  3") {
+		t.Errorf("expected a table row for /pokemon/{name}, got %q", out)
+	}
+}
+
+func TestEndpointPattern(t *testing.T) {
+	base := "https://pokeapi.co/api/v2"
+	for url, want := range map[string]string{
+		base + "/pokemon/pikachu":            "/pokemon/{name}",
+		base + "/pokemon/25":                 "/pokemon/{name}",
+		base + "/pokemon/pikachu/encounters": "/pokemon/{name}/encounters",
+		base + "/location-area?offset=20":    "/location-area",
+		base + "/location-area/":             "/location-area",
+	} {
+		if got := endpointPattern(base, url); got != want {
+			t.Errorf("endpointPattern(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
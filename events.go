@@ -0,0 +1,68 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Event types written to the -events stream
+const (
+	eventRequest  = "request"
+	eventCacheHit = "cache_hit"
+	eventCatch    = "catch"
+	eventExit     = "exit"
+)
+
+// Event is a single JSON line in the -events stream
+type Event struct {
+	Type    string    `json:"type"`
+	Time    time.Time `json:"time"`
+	URL     string    `json:"url,omitempty"`
+	Pokemon string    `json:"pokemon,omitempty"`
+	Chance  int       `json:"chance,omitempty"`
+	Roll    int       `json:"roll,omitempty"`
+	Outcome string    `json:"outcome,omitempty"`
+}
+
+// eventLog appends events to a file as JSON lines; a nil *eventLog discards events
+type eventLog struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// openEventLog opens path for appending, creating it if needed
+func openEventLog(path string) (*eventLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening events file: %w", err)
+	}
+	return &eventLog{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// emit writes e, stamping it with the current time if unset
+func (l *eventLog) emit(e Event) {
+	if l == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if err := l.enc.Encode(e); err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing event: %v\n", err)
+	}
+}
+
+// Close closes the underlying file
+func (l *eventLog) Close() error {
+	if l == nil {
+		return nil
+	}
+	return l.file.Close()
+}
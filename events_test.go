@@ -0,0 +1,67 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEventStream(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":0}`,
+	})
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	events, err := openEventLog(path)
+	if err != nil {
+		t.Fatalf("openEventLog: %v", err)
+	}
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.events = events
+
+	captureOutput(t, func() {
+		runREPL(strings.NewReader("catch caterpie\ncatch caterpie\nexit\n"), cfg)
+	})
+	if err := events.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open events file: %v", err)
+	}
+	defer f.Close()
+
+	var types []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid event line %q: %v", scanner.Text(), err)
+		}
+		if e.Time.IsZero() {
+			t.Errorf("event %q has no timestamp", e.Type)
+		}
+		if e.Type == eventCatch && (e.Pokemon != "caterpie" || e.Outcome == "") {
+			t.Errorf("unexpected catch event: %+v", e)
+		}
+		types = append(types, e.Type)
+	}
+
+	want := []string{eventRequest, eventCatch, eventCacheHit}
+	if len(types) < len(want) {
+		t.Fatalf("expected at least %d events, got %v", len(want), types)
+	}
+	for i, typ := range want {
+		if types[i] != typ {
+			t.Errorf("event %d: expected %q, got %q (all: %v)", i, typ, types[i], types)
+		}
+	}
+	if types[len(types)-1] != eventExit {
+		t.Errorf("expected last event to be %q, got %v", eventExit, types)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// EvolutionChainResponse is the /evolution-chain/{id} response
+type EvolutionChainResponse struct {
+	Chain evolutionLink `json:"chain"`
+}
+
+// evolutionLink is one stage of an evolution chain and the stages it evolves into
+type evolutionLink struct {
+	Species struct {
+		Name string `json:"name"`
+	} `json:"species"`
+	EvolvesTo []evolutionLink `json:"evolves_to"`
+}
+
+// nextEvolutions returns the species name evolves into within link's chain,
+// and whether name was found at all
+func nextEvolutions(link evolutionLink, name string) ([]string, bool) {
+	if link.Species.Name == name {
+		next := make([]string, 0, len(link.EvolvesTo))
+		for _, to := range link.EvolvesTo {
+			next = append(next, to.Species.Name)
+		}
+		return next, true
+	}
+	for _, to := range link.EvolvesTo {
+		if next, ok := nextEvolutions(to, name); ok {
+			return next, true
+		}
+	}
+	return nil, false
+}
+
+// evolutionHint returns "name can still evolve into X", or "" if name is fully
+// evolved, doesn't evolve, or its chain can't be fetched
+func evolutionHint(ctx context.Context, cfg *config, name string) string {
+	species, err := fetchSpecies(ctx, cfg, name)
+	if err != nil || species.EvolutionChain.URL == "" {
+		return ""
+	}
+	chain, err := getJSON[EvolutionChainResponse](ctx, cfg, species.EvolutionChain.URL)
+	if err != nil {
+		return ""
+	}
+	next, _ := nextEvolutions(chain.Chain, name)
+	if len(next) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%s can still evolve into %s", name, strings.Join(next, " or "))
+}
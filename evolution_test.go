@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestEvolutionHints(t *testing.T) {
+	// Species point at their chain with an absolute URL, so "{srv}" is filled in per request
+	routes := map[string]string{
+		"/pokemon-species/bulbasaur": `{"evolution_chain":{"url":"{srv}/evolution-chain/1"}}`,
+		"/pokemon-species/venusaur":  `{"evolution_chain":{"url":"{srv}/evolution-chain/1"}}`,
+		"/pokemon-species/eevee":     `{"evolution_chain":{"url":"{srv}/evolution-chain/67"}}`,
+		"/pokemon-species/tauros":    `{"evolution_chain":{"url":"{srv}/evolution-chain/59"}}`,
+		"/evolution-chain/1": `{"chain":{"species":{"name":"bulbasaur"},"evolves_to":[
+			{"species":{"name":"ivysaur"},"evolves_to":[{"species":{"name":"venusaur"},"evolves_to":[]}]}
+		]}}`,
+		"/evolution-chain/67": `{"chain":{"species":{"name":"eevee"},"evolves_to":[
+			{"species":{"name":"vaporeon"},"evolves_to":[]},
+			{"species":{"name":"jolteon"},"evolves_to":[]}
+		]}}`,
+		"/evolution-chain/59": `{"chain":{"species":{"name":"tauros"},"evolves_to":[]}}`,
+	}
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		io.WriteString(w, strings.ReplaceAll(body, "{srv}", srv.URL))
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	catch := func(name string) string {
+		return captureOutput(t, func() {
+			throwBall(context.Background(), cfg, Pokemon{Name: name}, defaultBall, 100)
+		})
+	}
+
+	if out := catch("bulbasaur"); strings.Contains(out, "evolve") {
+		t.Errorf("expected no hint without -evo-hints, got %q", out)
+	}
+
+	cfg.evoHints = true
+	for name, want := range map[string]string{
+		"bulbasaur": "bulbasaur can still evolve into ivysaur\n",
+		"eevee":     "eevee can still evolve into vaporeon or jolteon\n",
+		"venusaur":  "", // fully evolved
+		"tauros":    "", // doesn't evolve
+		"missingno": "", // no species data
+	} {
+		delete(cfg.pokedex, name)
+		out := catch(name)
+		if want == "" && strings.Contains(out, "evolve") {
+			t.Errorf("%s: expected no hint, got %q", name, out)
+		}
+		if want != "" && !strings.Contains(out, want) {
+			t.Errorf("%s: expected %q, got %q", name, want, out)
+		}
+	}
+}
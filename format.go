@@ -0,0 +1,66 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// writeJSON writes v to w as indented JSON followed by a newline. Callers pass
+// sorted slices rather than maps where order matters so output is reproducible.
+func writeJSON(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding JSON: %w", err)
+	}
+	data = append(data, '\n')
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("error writing JSON: %w", err)
+	}
+	return nil
+}
+
+// flavorf prints decorative output that -quiet suppresses. Results and
+// errors are printed directly so they stay visible in quiet mode.
+func flavorf(cfg *config, format string, a ...any) {
+	if cfg.quiet {
+		return
+	}
+	fmt.Printf(format, a...)
+}
+
+// verbosef prints a diagnostic to stderr, only with -verbose
+func verbosef(cfg *config, format string, a ...any) {
+	if !cfg.verbose {
+		return
+	}
+	fmt.Fprintf(os.Stderr, format, a...)
+}
+
+// formatThousands formats n with comma thousands separators, e.g. -1234567 -> "-1,234,567"
+func formatThousands(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	out := make([]byte, 0, len(digits)+len(digits)/3)
+	for i := range len(digits) {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, ',')
+		}
+		out = append(out, digits[i])
+	}
+	return sign + string(out)
+}
+
+// formatCount formats n for display, with separators only when -pretty is set
+func formatCount(cfg *config, n int) string {
+	if cfg.pretty {
+		return formatThousands(n)
+	}
+	return strconv.Itoa(n)
+}
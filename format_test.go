@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestFormatThousands(t *testing.T) {
+	cases := []struct {
+		input    int
+		expected string
+	}{
+		{input: 0, expected: "0"},
+		{input: 7, expected: "7"},
+		{input: 999, expected: "999"},
+		{input: 1000, expected: "1,000"},
+		{input: 123456, expected: "123,456"},
+		{input: 1234567, expected: "1,234,567"},
+		{input: -5, expected: "-5"},
+		{input: -999, expected: "-999"},
+		{input: -1000, expected: "-1,000"},
+		{input: -1234567, expected: "-1,234,567"},
+	}
+
+	for _, c := range cases {
+		if actual := formatThousands(c.input); actual != c.expected {
+			t.Errorf("formatThousands(%d) = %q, expected %q", c.input, actual, c.expected)
+		}
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	cfg := newTestConfig(t)
+	if got := formatCount(cfg, 12345); got != "12345" {
+		t.Errorf("expected plain number by default, got %q", got)
+	}
+	cfg.pretty = true
+	if got := formatCount(cfg, 12345); got != "12,345" {
+		t.Errorf("expected separators with -pretty, got %q", got)
+	}
+}
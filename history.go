@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxExploreHistory caps how many explored areas back can walk through
+const maxExploreHistory = 20
+
+// exploreHistory is a browser-style list of explored areas: visiting an area
+// after going back drops the areas ahead of it
+type exploreHistory struct {
+	areas []string
+	pos   int // index of the current area in areas
+}
+
+// visit records name as the current area
+func (h *exploreHistory) visit(name string) {
+	if len(h.areas) > 0 {
+		if h.areas[h.pos] == name {
+			return
+		}
+		h.areas = h.areas[:h.pos+1]
+	}
+	h.areas = append(h.areas, name)
+	if len(h.areas) > maxExploreHistory {
+		h.areas = h.areas[len(h.areas)-maxExploreHistory:]
+	}
+	h.pos = len(h.areas) - 1
+}
+
+// back moves to the previous area, reporting false at the start of the history
+func (h *exploreHistory) back() (string, bool) {
+	if h.pos == 0 {
+		return "", false
+	}
+	h.pos--
+	return h.areas[h.pos], true
+}
+
+// forward moves to the next area, reporting false at the end of the history
+func (h *exploreHistory) forward() (string, bool) {
+	if h.pos+1 >= len(h.areas) {
+		return "", false
+	}
+	h.pos++
+	return h.areas[h.pos], true
+}
+
+// commandBack explores the previous area in the history again
+func commandBack(ctx context.Context, cfg *config, args ...[]string) error {
+	name, ok := cfg.history.back()
+	if !ok {
+		fmt.Println("No earlier area to go back to.")
+		return nil
+	}
+	return showArea(ctx, cfg, name, false, false)
+}
+
+// commandForward explores the next area in the history again
+func commandForward(ctx context.Context, cfg *config, args ...[]string) error {
+	name, ok := cfg.history.forward()
+	if !ok {
+		fmt.Println("No later area to go forward to.")
+		return nil
+	}
+	return showArea(ctx, cfg, name, false, false)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestExploreBackForward(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		area := strings.TrimPrefix(r.URL.Path, "/location-area/")
+		io.WriteString(w, fmt.Sprintf(`{"pokemon_encounters":[{"pokemon":{"name":"%s-mon"}}]}`, area))
+	}))
+	defer srv.Close()
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	run := func(input string) string {
+		return captureOutput(t, func() {
+			processInput(input, cfg)
+		})
+	}
+
+	if out := run("back"); !strings.Contains(out, "No earlier area to go back to.") {
+		t.Errorf("expected an empty history message, got %q", out)
+	}
+	run("explore area-a")
+	run("explore area-b")
+	run("explore area-c")
+
+	steps := []struct {
+		input, want string
+	}{
+		{"back", "area-b-mon"},
+		{"back", "area-a-mon"},
+		{"back", "No earlier area to go back to."},
+		{"forward", "area-b-mon"},
+		{"forward", "area-c-mon"},
+		{"forward", "No later area to go forward to."},
+	}
+	for _, step := range steps {
+		if out := run(step.input); !strings.Contains(out, step.want) {
+			t.Errorf("%s: expected %q, got %q", step.input, step.want, out)
+		}
+	}
+	if requests != 3 {
+		t.Errorf("navigation should reuse cached areas, got %d requests", requests)
+	}
+
+	// Exploring somewhere new after going back drops the areas ahead
+	run("back")
+	run("explore area-d")
+	if out := run("forward"); !strings.Contains(out, "No later area") {
+		t.Errorf("expected no forward history after a new explore, got %q", out)
+	}
+	if out := run("back"); !strings.Contains(out, "area-b-mon") {
+		t.Errorf("expected back to return to area-b, got %q", out)
+	}
+}
+
+func TestExploreHistoryCap(t *testing.T) {
+	var h exploreHistory
+	for i := range maxExploreHistory + 5 {
+		h.visit(fmt.Sprintf("area-%d", i))
+	}
+	if len(h.areas) != maxExploreHistory {
+		t.Fatalf("expected history capped at %d, got %d", maxExploreHistory, len(h.areas))
+	}
+	steps := 0
+	for {
+		if _, ok := h.back(); !ok {
+			break
+		}
+		steps++
+	}
+	if steps != maxExploreHistory-1 {
+		t.Errorf("expected %d steps back, got %d", maxExploreHistory-1, steps)
+	}
+}
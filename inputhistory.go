@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// maxInputHistory caps how many entered commands are remembered per session
+	maxInputHistory = 500
+	// defaultHistoryCount is how many commands a bare history prints
+	defaultHistoryCount = 10
+)
+
+// recordInput appends an entered command line to the session history,
+// dropping the oldest once maxInputHistory is reached
+func recordInput(cfg *config, line string) {
+	cfg.inputHistory = append(cfg.inputHistory, line)
+	if len(cfg.inputHistory) > maxInputHistory {
+		cfg.inputHistory = cfg.inputHistory[len(cfg.inputHistory)-maxInputHistory:]
+	}
+}
+
+// lastInputs returns up to n of the most recent history entries, oldest first
+func lastInputs(history []string, n int) []string {
+	if n > len(history) {
+		n = len(history)
+	}
+	return history[len(history)-n:]
+}
+
+// commandHistory prints the last N commands entered this session, numbered
+// from the start of the session
+func commandHistory(ctx context.Context, cfg *config, args ...[]string) error {
+	n := defaultHistoryCount
+	if len(args) > 0 && len(args[0]) > 0 {
+		var err error
+		n, err = strconv.Atoi(args[0][0])
+		if err != nil || n < 1 {
+			return userErrorf("Usage: history [count]")
+		}
+	}
+
+	entries := lastInputs(cfg.inputHistory, n)
+	first := len(cfg.inputHistory) - len(entries) + 1
+	for i, line := range entries {
+		fmt.Printf("%4d  %s\n", first+i, line)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRecordInput(t *testing.T) {
+	cfg := newTestConfig(t)
+	for i := range maxInputHistory + 3 {
+		recordInput(cfg, fmt.Sprintf("explore area-%d", i))
+	}
+	if len(cfg.inputHistory) != maxInputHistory {
+		t.Fatalf("expected history capped at %d, got %d", maxInputHistory, len(cfg.inputHistory))
+	}
+	if cfg.inputHistory[0] != "explore area-3" {
+		t.Errorf("expected the oldest entries dropped, got %q first", cfg.inputHistory[0])
+	}
+
+	if got := lastInputs([]string{"a", "b", "c"}, 2); !reflect.DeepEqual(got, []string{"b", "c"}) {
+		t.Errorf("expected the last 2 entries, got %v", got)
+	}
+	if got := lastInputs([]string{"a"}, 5); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Errorf("expected every entry when asking for more, got %v", got)
+	}
+}
+
+func TestHistoryCommand(t *testing.T) {
+	cfg := newTestConfig(t)
+	out := captureOutput(t, func() {
+		runREPL(strings.NewReader("help\n\npokedex\nhistory 2\n"), cfg)
+	})
+	if !reflect.DeepEqual(cfg.inputHistory, []string{"help", "pokedex", "history 2"}) {
+		t.Errorf("expected entered lines recorded without blanks, got %q", cfg.inputHistory)
+	}
+	if !strings.Contains(out, "   2  pokedex\n   3  history 2\n") || strings.Contains(out, "1  help") {
+		t.Errorf("expected the last 2 commands numbered, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("history zero", cfg)
+	})
+	if !strings.Contains(out, "Usage: history [count]") {
+		t.Errorf("expected a usage message, got %q", out)
+	}
+}
@@ -0,0 +1,150 @@
+package pokecache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// diskEntry is the file written for each entry of a persistent cache. The
+// key is kept so a hash collision can't serve another URL's value.
+type diskEntry struct {
+	Key   string     `json:"key"`
+	Entry CacheEntry `json:"entry"`
+}
+
+// NewPersistentCache creates a cache that also writes every entry to a file
+// in dir, so a fresh cache on the same dir can serve it after a restart.
+// Entries on disk expire after interval like those in memory.
+func NewPersistentCache(interval time.Duration, dir string) (*Cache, error) {
+	c := NewCache(interval)
+	if err := c.SetDir(dir); err != nil {
+		c.Stop()
+		return nil, err
+	}
+	return c, nil
+}
+
+// SetDir adds a disk tier in dir to any cache: entries are also written
+// there, and a memory miss checks it before reporting not found. The byte and
+// entry limits apply to memory only. Call it before the cache is used.
+func (c *Cache) SetDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("error creating cache directory: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dir = dir
+	return nil
+}
+
+// diskPath returns the file key is persisted in
+func (c *Cache) diskPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// writeDisk persists entry under key. The disk tier is best effort, so a
+// failed write only means the entry won't survive a restart. It does file
+// I/O, so callers must not hold c.mu.
+func (c *Cache) writeDisk(key string, entry CacheEntry) {
+	if c.dir == "" {
+		return
+	}
+	data, err := json.Marshal(diskEntry{Key: key, Entry: entry})
+	if err != nil {
+		return
+	}
+	// Write then rename, so a crash never leaves half a file behind
+	path := c.diskPath(key)
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil || os.Rename(tmp.Name(), path) != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// removeDisk deletes key's file, if any. Callers must not hold c.mu.
+func (c *Cache) removeDisk(key string) {
+	if c.dir != "" {
+		os.Remove(c.diskPath(key))
+	}
+}
+
+// readDisk returns key's entry from disk. Missing, unreadable and corrupt
+// files count as a miss; an expired file is deleted. Callers must not hold c.mu.
+func (c *Cache) readDisk(key string, now time.Time) (CacheEntry, bool) {
+	data, err := os.ReadFile(c.diskPath(key))
+	if err != nil {
+		return CacheEntry{}, false
+	}
+	var stored diskEntry
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Key != key {
+		return CacheEntry{}, false
+	}
+	if c.expired(stored.Entry, now) {
+		c.removeDisk(key)
+		return CacheEntry{}, false
+	}
+	return stored.Entry, true
+}
+
+// loadDisk reads key's file into memory after a memory miss
+func (c *Cache) loadDisk(key string) (CacheEntry, bool, time.Time) {
+	c.mu.RLock()
+	now := c.now()
+	c.mu.RUnlock()
+
+	entry, ok := c.readDisk(key, now)
+	if !ok {
+		return CacheEntry{}, false, now
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.maxBytes > 0 && len(entry.Val) > c.maxBytes {
+		return entry, true, now
+	}
+	c.store(key, entry)
+	c.evictOverBudget()
+	c.evictOverLimit()
+	return entry, true, now
+}
+
+// reapDisk deletes expired and unreadable files from the disk tier, including
+// ones this process never loaded. Callers must not hold c.mu.
+func (c *Cache) reapDisk(now time.Time) {
+	if c.dir == "" {
+		return
+	}
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		path := filepath.Join(c.dir, name)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var stored diskEntry
+		if err := json.Unmarshal(data, &stored); err != nil || c.expired(stored.Entry, now) {
+			os.Remove(path)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package pokecache
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestPersistentCacheSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	key := "https://pokeapi.co/api/v2/location-area"
+
+	first, err := NewPersistentCache(time.Minute, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Add(key, []byte("areas"))
+	first.Stop()
+
+	second, err := NewPersistentCache(time.Minute, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Stop()
+	if second.Len() != 0 {
+		t.Fatalf("expected a fresh cache to start with nothing in memory, got %d entries", second.Len())
+	}
+	val, ok := second.Get(key)
+	if !ok || string(val) != "areas" {
+		t.Fatalf("expected the value served from disk, got %q (found=%v)", val, ok)
+	}
+	if second.Len() != 1 {
+		t.Errorf("expected the disk hit to be kept in memory, got %d entries", second.Len())
+	}
+
+	second.Delete(key)
+	third, err := NewPersistentCache(time.Minute, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer third.Stop()
+	if _, ok := third.Get(key); ok {
+		t.Error("expected Delete to remove the entry from disk too")
+	}
+}
+
+func TestPersistentCacheExpiry(t *testing.T) {
+	dir := t.TempDir()
+	key := "https://pokeapi.co/api/v2/pokemon/pikachu"
+	now := time.Now()
+
+	first, err := NewPersistentCache(time.Minute, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer first.Stop()
+	first.SetClock(func() time.Time { return now.Add(-2 * time.Minute) })
+	first.Add(key, []byte("pikachu"))
+
+	second, err := NewPersistentCache(time.Minute, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Stop()
+	if _, ok := second.Get(key); ok {
+		t.Error("expected an entry older than the interval to be ignored")
+	}
+	if _, err := os.Stat(second.diskPath(key)); !os.IsNotExist(err) {
+		t.Errorf("expected the expired file to be removed, got %v", err)
+	}
+}
+
+func TestPersistentCacheCorruptFile(t *testing.T) {
+	dir := t.TempDir()
+	key := "https://pokeapi.co/api/v2/pokemon/eevee"
+
+	cache, err := NewPersistentCache(time.Minute, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cache.Stop()
+	if err := os.WriteFile(cache.diskPath(key), []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Get(key); ok {
+		t.Error("expected a corrupt file to count as a miss")
+	}
+
+	cache.Add(key, []byte("eevee"))
+	if val, ok := cache.Get(key); !ok || string(val) != "eevee" {
+		t.Errorf("expected Add to replace the corrupt file, got %q (found=%v)", val, ok)
+	}
+}
+
+func TestSetDirWithMaxBytes(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewCacheWithMaxBytes(time.Minute, 10)
+	defer cache.Stop()
+	if err := cache.SetDir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	cache.Add("small", []byte("12345"))
+	cache.Add("large", []byte("0123456789abc"))
+	if cache.SizeBytes() > 10 {
+		t.Errorf("expected memory to stay within 10 bytes, got %d", cache.SizeBytes())
+	}
+
+	fresh, err := NewPersistentCache(time.Minute, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fresh.Stop()
+	for _, key := range []string{"small", "large"} {
+		if _, ok := fresh.Get(key); !ok {
+			t.Errorf("expected %s on disk despite the memory limit", key)
+		}
+	}
+}
+
+func TestReapExpiredRemovesFiles(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+
+	cache, err := NewPersistentCache(time.Minute, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cache.Stop()
+	cache.SetClock(func() time.Time { return now.Add(-2 * time.Minute) })
+	cache.Add("stale", []byte("old"))
+	cache.SetClock(func() time.Time { return now })
+	cache.Add("fresh", []byte("new"))
+
+	cache.reapExpired()
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("expected only the fresh entry's file left, got %d files", len(files))
+	}
+	if _, err := os.Stat(cache.diskPath("fresh")); err != nil {
+		t.Errorf("expected the fresh entry's file to remain: %v", err)
+	}
+}
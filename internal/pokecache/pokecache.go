@@ -2,7 +2,10 @@
 package pokecache
 
 import (
+	"container/list"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -11,11 +14,25 @@ type Cache struct {
 	interval time.Duration
 	mu       *sync.RWMutex
 	stopChan chan struct{}
+	stopOnce sync.Once
+	size     int // total bytes of all stored values
+	maxBytes int // 0 means unbounded
+	jitter   float64
+	rng      *rand.Rand
+	now      func() time.Time
+	hits     atomic.Int64 // lookups that found their key; atomic since Get only holds a read lock
+	misses   atomic.Int64
+
+	maxEntries int                      // caps the entry count, evicting the least recently used; 0 means unbounded
+	order      *list.List               // keys, most recently used first; nil unless maxEntries > 0
+	elems      map[string]*list.Element // each key's element in order
+	dir        string                   // where entries are also written, empty for memory only
 }
 
 type CacheEntry struct {
-	CreatedAt time.Time `json:"created_at"`
-	Val       []byte    `json:"val"`
+	CreatedAt time.Time     `json:"created_at"`
+	Val       []byte        `json:"val"`
+	TTL       time.Duration `json:"ttl,omitempty"` // zero means the cache interval
 }
 
 func NewCache(interval time.Duration) *Cache {
@@ -24,6 +41,7 @@ func NewCache(interval time.Duration) *Cache {
 		interval: interval,
 		mu:       &sync.RWMutex{},
 		stopChan: make(chan struct{}),
+		now:      time.Now,
 	}
 
 	// Start the reap loop in a goroutine
@@ -32,21 +50,146 @@ func NewCache(interval time.Duration) *Cache {
 	return c
 }
 
+// NewCacheWithMaxBytes creates a cache that evicts its oldest entries
+// whenever the total size of stored values would exceed maxBytes
+func NewCacheWithMaxBytes(interval time.Duration, maxBytes int) *Cache {
+	c := NewCache(interval)
+	c.maxBytes = maxBytes
+	return c
+}
+
+// NewCacheWithLimit creates a cache holding at most maxEntries entries,
+// evicting the least recently used (added or read) entry to make room
+func NewCacheWithLimit(interval time.Duration, maxEntries int) *Cache {
+	c := NewCache(interval)
+	if maxEntries > 0 {
+		c.maxEntries = maxEntries
+		c.order = list.New()
+		c.elems = make(map[string]*list.Element)
+	}
+	return c
+}
+
+// SetJitter spreads expirations by giving each new entry a TTL drawn uniformly
+// from interval ± fraction*interval, so the average TTL stays at interval.
+// rng makes the spread reproducible; a fraction of 0 disables jitter.
+func (c *Cache) SetJitter(fraction float64, rng *rand.Rand) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.jitter = fraction
+	c.rng = rng
+}
+
+// SetClock replaces the time source used for timestamps and ages (for testing)
+func (c *Cache) SetClock(now func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
 func (c *Cache) Add(key string, val []byte) {
+	c.mu.Lock()
 	ce := CacheEntry{
-		CreatedAt: time.Now(),
+		CreatedAt: c.now(),
 		Val:       val,
 	}
 
+	if c.jitter > 0 && c.rng != nil {
+		offset := c.jitter * (2*c.rng.Float64() - 1)
+		ce.TTL = time.Duration(float64(c.interval) * (1 + offset))
+	}
+
+	// A value larger than the whole budget can never fit in memory
+	if c.maxBytes == 0 || len(val) <= c.maxBytes {
+		c.store(key, ce)
+		c.evictOverBudget()
+		c.evictOverLimit()
+	}
+	c.mu.Unlock()
+
+	c.writeDisk(key, ce)
+}
+
+// store sets key to entry, keeping the byte count in sync. Callers hold c.mu.
+func (c *Cache) store(key string, entry CacheEntry) {
+	if old, ok := c.cache[key]; ok {
+		c.size -= len(old.Val)
+	}
+	c.cache[key] = entry
+	c.size += len(entry.Val)
+	c.touch(key)
+}
+
+// touch marks key as the most recently used. Callers hold c.mu for writing.
+func (c *Cache) touch(key string) {
+	if c.order == nil {
+		return
+	}
+	if elem, ok := c.elems[key]; ok {
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.elems[key] = c.order.PushFront(key)
+}
+
+// remove deletes key, keeping the byte count in sync. Callers hold c.mu.
+func (c *Cache) remove(key string) {
+	if old, ok := c.cache[key]; ok {
+		c.size -= len(old.Val)
+		delete(c.cache, key)
+	}
+	if elem, ok := c.elems[key]; ok {
+		c.order.Remove(elem)
+		delete(c.elems, key)
+	}
+}
+
+// evictOverLimit removes the least recently used entries until the cache
+// holds at most maxEntries. Callers hold c.mu.
+func (c *Cache) evictOverLimit() {
+	for c.maxEntries > 0 && len(c.cache) > c.maxEntries {
+		c.remove(c.order.Back().Value.(string))
+	}
+}
+
+// evictOverBudget removes the oldest entries (by CreatedAt) until the
+// cache fits within maxBytes. Callers hold c.mu.
+func (c *Cache) evictOverBudget() {
+	for c.maxBytes > 0 && c.size > c.maxBytes {
+		oldestKey := ""
+		var oldest time.Time
+		for key, entry := range c.cache {
+			if oldestKey == "" || entry.CreatedAt.Before(oldest) {
+				oldestKey, oldest = key, entry.CreatedAt
+			}
+		}
+		c.remove(oldestKey)
+	}
+}
+
+// Delete removes key from the cache, and from disk for a persistent cache, if present
+func (c *Cache) Delete(key string) {
 	c.mu.Lock()
-	c.cache[key] = ce
+	c.remove(key)
 	c.mu.Unlock()
+	c.removeDisk(key)
 }
 
-func (c *Cache) Get(key string) ([]byte, bool) {
+// SizeBytes returns the total size of all cached values
+func (c *Cache) SizeBytes() int {
 	c.mu.RLock()
-	entry, ok := c.cache[key]
-	c.mu.RUnlock()
+	defer c.mu.RUnlock()
+	return c.size
+}
+
+// MaxBytes returns the byte limit, or 0 if the cache is unbounded
+func (c *Cache) MaxBytes() int {
+	return c.maxBytes
+}
+
+func (c *Cache) Get(key string) ([]byte, bool) {
+	entry, ok, _ := c.lookup(key)
+	c.count(ok)
 
 	if !ok {
 		return []byte{}, false
@@ -60,6 +203,93 @@ func (c *Cache) Get(key string) ([]byte, bool) {
 	return entry.Val, true
 }
 
+// lookup returns the entry for key and the current time, falling back to
+// disk for a persistent cache
+func (c *Cache) lookup(key string) (CacheEntry, bool, time.Time) {
+	entry, ok, now := c.lookupMemory(key)
+	if ok || c.dir == "" {
+		return entry, ok, now
+	}
+	return c.loadDisk(key)
+}
+
+// lookupMemory is lookup without the disk tier. With an entry limit it takes
+// the write lock, since a read changes the eviction order.
+func (c *Cache) lookupMemory(key string) (CacheEntry, bool, time.Time) {
+	if c.order == nil {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		entry, ok := c.cache[key]
+		return entry, ok, c.now()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.cache[key]
+	if ok {
+		c.touch(key)
+	}
+	return entry, ok, c.now()
+}
+
+// count records the outcome of a lookup
+func (c *Cache) count(found bool) {
+	if found {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+}
+
+// Stats returns how many lookups found their key and how many didn't
+func (c *Cache) Stats() (hits, misses int) {
+	return int(c.hits.Load()), int(c.misses.Load())
+}
+
+// Merge adds entries that are neither expired nor already cached, keeping
+// their original CreatedAt, and returns how many were added
+func (c *Cache) Merge(entries map[string]CacheEntry) int {
+	c.mu.Lock()
+	now := c.now()
+
+	added := make(map[string]CacheEntry)
+	for key, entry := range entries {
+		if c.expired(entry, now) {
+			continue
+		}
+		if _, ok := c.cache[key]; ok {
+			continue
+		}
+		if c.maxBytes > 0 && len(entry.Val) > c.maxBytes {
+			continue
+		}
+		c.store(key, entry)
+		added[key] = entry
+	}
+	c.evictOverBudget()
+	c.evictOverLimit()
+	c.mu.Unlock()
+
+	for key, entry := range added {
+		c.writeDisk(key, entry)
+	}
+	return len(added)
+}
+
+// GetWithAge returns the value for key along with how long ago it was added
+func (c *Cache) GetWithAge(key string) ([]byte, time.Duration, bool) {
+	entry, ok, now := c.lookup(key)
+	c.count(ok)
+
+	if !ok {
+		return []byte{}, 0, false
+	}
+	if entry.Val == nil {
+		entry.Val = []byte{}
+	}
+	return entry.Val, now.Sub(entry.CreatedAt), true
+}
+
 func (c *Cache) reapLoop() {
 	ticker := time.NewTicker(c.interval)
 	defer ticker.Stop()
@@ -75,20 +305,43 @@ func (c *Cache) reapLoop() {
 }
 
 func (c *Cache) reapExpired() {
-	now := time.Now()
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
+	now := c.now()
 	for key, entry := range c.cache {
-		// If the entry is older than the interval, remove it
-		if now.Sub(entry.CreatedAt) > c.interval {
-			delete(c.cache, key)
+		// If the entry is older than its TTL, remove it
+		if c.expired(entry, now) {
+			c.remove(key)
 		}
 	}
+	c.mu.Unlock()
+
+	c.reapDisk(now)
 }
 
+// expired reports whether entry has outlived its TTL (or the interval if it has none)
+func (c *Cache) expired(entry CacheEntry, now time.Time) bool {
+	ttl := entry.TTL
+	if ttl == 0 {
+		ttl = c.interval
+	}
+	return now.Sub(entry.CreatedAt) > ttl
+}
+
+// Stop ends the reap loop. It is safe to call more than once.
 func (c *Cache) Stop() {
-	close(c.stopChan)
+	c.stopOnce.Do(func() {
+		close(c.stopChan)
+	})
+}
+
+// Running reports whether the reap loop is still running, i.e. Stop has not been called
+func (c *Cache) Running() bool {
+	select {
+	case <-c.stopChan:
+		return false
+	default:
+		return true
+	}
 }
 
 // GetInterval returns the cache interval (for testing)
@@ -96,7 +349,7 @@ func (c *Cache) GetInterval() time.Duration {
 	return c.interval
 }
 
-// GetCacheMap returns a copy of the cache map (for testing)
+// GetCacheMap returns a copy of the cache map (for testing and export)
 func (c *Cache) GetCacheMap() map[string]CacheEntry {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -2,6 +2,7 @@ package pokecache
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 	"time"
 )
@@ -80,6 +81,38 @@ func TestCacheGet(t *testing.T) {
 	}
 }
 
+func TestCacheHitMissStats(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Stop()
+
+	cache.Get("pikachu")
+	cache.Add("pikachu", []byte("pika"))
+	cache.Get("pikachu")
+	cache.GetWithAge("pikachu")
+
+	hits, misses := cache.Stats()
+	if hits != 2 || misses != 1 {
+		t.Errorf("Expected 2 hits and 1 miss, got %d hits and %d misses", hits, misses)
+	}
+
+	// Concurrent lookups must all be counted
+	done := make(chan bool)
+	for i := 0; i < 50; i++ {
+		go func() {
+			cache.Get("pikachu")
+			cache.Get("raichu")
+			done <- true
+		}()
+	}
+	for i := 0; i < 50; i++ {
+		<-done
+	}
+	hits, misses = cache.Stats()
+	if hits != 52 || misses != 51 {
+		t.Errorf("Expected 52 hits and 51 misses, got %d hits and %d misses", hits, misses)
+	}
+}
+
 func TestCacheGetEmpty(t *testing.T) {
 	cache := NewCache(5 * time.Second)
 
@@ -376,3 +409,259 @@ func BenchmarkCacheConcurrentAccess(b *testing.B) {
 	})
 	cache.Stop()
 }
+
+func TestCacheMerge(t *testing.T) {
+	cache := NewCache(time.Minute)
+	defer cache.Stop()
+
+	cache.Add("existing", []byte("keep-me"))
+
+	added := cache.Merge(map[string]CacheEntry{
+		"existing": {CreatedAt: time.Now(), Val: []byte("overwrite")},
+		"fresh":    {CreatedAt: time.Now().Add(-time.Second), Val: []byte("fresh")},
+		"expired":  {CreatedAt: time.Now().Add(-2 * time.Minute), Val: []byte("stale")},
+	})
+	if added != 1 {
+		t.Errorf("Expected 1 entry added, got %d", added)
+	}
+
+	if val, _ := cache.Get("existing"); string(val) != "keep-me" {
+		t.Errorf("Merge should not replace existing entries, got %s", string(val))
+	}
+	if _, found := cache.Get("fresh"); !found {
+		t.Error("Expected fresh entry to be merged")
+	}
+	if _, found := cache.Get("expired"); found {
+		t.Error("Expected expired entry to be skipped")
+	}
+}
+
+func TestCacheSizeBytes(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Stop()
+
+	cache.Add("a", make([]byte, 10))
+	cache.Add("b", make([]byte, 20))
+	if got := cache.SizeBytes(); got != 30 {
+		t.Errorf("Expected 30 bytes, got %d", got)
+	}
+
+	// Overwrites replace the old size rather than adding to it
+	cache.Add("a", make([]byte, 5))
+	if got := cache.SizeBytes(); got != 25 {
+		t.Errorf("Expected 25 bytes after overwrite, got %d", got)
+	}
+}
+
+func TestCacheSizeBytesAfterReap(t *testing.T) {
+	now := time.Now()
+	cache := NewCache(time.Minute)
+	defer cache.Stop()
+
+	cache.SetClock(func() time.Time { return now.Add(-2 * time.Minute) })
+	cache.Add("stale", make([]byte, 30))
+	cache.SetClock(func() time.Time { return now })
+	cache.Add("fresh", make([]byte, 12))
+
+	cache.reapExpired()
+	if got := cache.SizeBytes(); got != 12 {
+		t.Errorf("Expected reaping to release the stale entry's 30 bytes, got %d", got)
+	}
+}
+
+func TestCacheDelete(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	defer cache.Stop()
+
+	cache.Add("a", make([]byte, 10))
+	cache.Add("b", make([]byte, 20))
+	cache.Delete("a")
+	cache.Delete("missing")
+
+	if _, ok := cache.Get("a"); ok {
+		t.Error("Expected a to be deleted")
+	}
+	if _, ok := cache.Get("b"); !ok {
+		t.Error("Expected b to remain")
+	}
+	if got := cache.SizeBytes(); got != 20 {
+		t.Errorf("Expected 20 bytes after delete, got %d", got)
+	}
+}
+
+func TestCacheMaxBytesEviction(t *testing.T) {
+	cache := NewCacheWithMaxBytes(5*time.Second, 100)
+	defer cache.Stop()
+
+	cache.Add("oldest", make([]byte, 40))
+	time.Sleep(time.Millisecond)
+	cache.Add("middle", make([]byte, 40))
+	time.Sleep(time.Millisecond)
+	cache.Add("newest", make([]byte, 40))
+
+	if got := cache.SizeBytes(); got > 100 {
+		t.Errorf("Expected usage under the 100 byte cap, got %d", got)
+	}
+	if _, found := cache.Get("oldest"); found {
+		t.Error("Expected oldest entry to be evicted")
+	}
+	for _, key := range []string{"middle", "newest"} {
+		if _, found := cache.Get(key); !found {
+			t.Errorf("Expected %s to survive eviction", key)
+		}
+	}
+
+	// A single value bigger than the cap is never stored
+	cache.Add("oversized", make([]byte, 150))
+	if _, found := cache.Get("oversized"); found {
+		t.Error("Oversized value should not be cached")
+	}
+	if got := cache.SizeBytes(); got != 80 {
+		t.Errorf("Expected oversized add to leave usage at 80, got %d", got)
+	}
+}
+
+func TestCacheJitter(t *testing.T) {
+	interval := 10 * time.Second
+	cache := NewCache(interval)
+	defer cache.Stop()
+	cache.SetJitter(0.1, rand.New(rand.NewSource(42)))
+
+	numEntries := 1000
+	for i := 0; i < numEntries; i++ {
+		cache.Add(fmt.Sprintf("key-%d", i), []byte("value"))
+	}
+
+	low := time.Duration(float64(interval) * 0.9)
+	high := time.Duration(float64(interval) * 1.1)
+	var total time.Duration
+	distinct := make(map[time.Duration]bool)
+	for key, entry := range cache.GetCacheMap() {
+		if entry.TTL < low || entry.TTL > high {
+			t.Errorf("Entry %s has TTL %v outside [%v, %v]", key, entry.TTL, low, high)
+		}
+		total += entry.TTL
+		distinct[entry.TTL] = true
+	}
+
+	mean := total / time.Duration(numEntries)
+	if diff := mean - interval; diff < -interval/100 || diff > interval/100 {
+		t.Errorf("Expected mean TTL within 1%% of %v, got %v", interval, mean)
+	}
+	if len(distinct) < numEntries/2 {
+		t.Errorf("Expected TTLs to be spread out, got only %d distinct values", len(distinct))
+	}
+}
+
+func TestCacheJitterExpiresByEntryTTL(t *testing.T) {
+	cache := NewCache(time.Minute)
+	defer cache.Stop()
+
+	now := time.Now()
+	cache.Merge(map[string]CacheEntry{
+		"short": {CreatedAt: now.Add(-2 * time.Second), Val: []byte("a"), TTL: time.Second},
+		"long":  {CreatedAt: now.Add(-2 * time.Second), Val: []byte("b"), TTL: time.Hour},
+	})
+	if _, found := cache.Get("short"); found {
+		t.Error("Entry past its own TTL should not be merged")
+	}
+
+	cache.reapExpired()
+	if _, found := cache.Get("long"); !found {
+		t.Error("Entry within its TTL should survive a reap")
+	}
+}
+
+func TestCacheGetWithAge(t *testing.T) {
+	cache := NewCache(time.Minute)
+	defer cache.Stop()
+
+	now := time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC)
+	cache.SetClock(func() time.Time { return now })
+
+	cache.Add("key", []byte("value"))
+	now = now.Add(42 * time.Second)
+
+	val, age, found := cache.GetWithAge("key")
+	if !found {
+		t.Fatal("Expected to find key")
+	}
+	if string(val) != "value" {
+		t.Errorf("Expected value %q, got %q", "value", string(val))
+	}
+	if age != 42*time.Second {
+		t.Errorf("Expected age 42s, got %v", age)
+	}
+
+	if _, _, found := cache.GetWithAge("missing"); found {
+		t.Error("Expected not to find missing key")
+	}
+}
+
+func TestCacheStopTwice(t *testing.T) {
+	cache := NewCache(5 * time.Second)
+	if !cache.Running() {
+		t.Error("Expected a new cache to be running")
+	}
+
+	cache.Stop()
+	cache.Stop()
+	if cache.Running() {
+		t.Error("Expected the cache to be stopped")
+	}
+}
+
+func TestCacheLRULimit(t *testing.T) {
+	cache := NewCacheWithLimit(time.Hour, 3)
+	defer cache.Stop()
+
+	cache.Add("a", []byte("1"))
+	cache.Add("b", []byte("2"))
+	cache.Add("c", []byte("3"))
+
+	// Reading a makes b the least recently used
+	cache.Get("a")
+	cache.Add("d", []byte("4"))
+
+	if cache.Len() != 3 {
+		t.Errorf("Expected the cache capped at 3 entries, got %d", cache.Len())
+	}
+	if _, found := cache.Get("b"); found {
+		t.Error("Expected b, the least recently used, to be evicted")
+	}
+	for _, key := range []string{"a", "c", "d"} {
+		if _, found := cache.Get(key); !found {
+			t.Errorf("Expected %s to survive", key)
+		}
+	}
+
+	// Overwriting counts as a use too
+	cache.GetWithAge("a")
+	cache.GetWithAge("d")
+	cache.Add("c", []byte("30"))
+	cache.Add("e", []byte("5"))
+	if _, found := cache.Get("a"); found {
+		t.Error("Expected a to be evicted after c was rewritten")
+	}
+	if cache.SizeBytes() != 4 {
+		t.Errorf("Expected evictions to keep the size in sync, got %d", cache.SizeBytes())
+	}
+
+	cache.Delete("e")
+	cache.Add("f", []byte("6"))
+	cache.Add("g", []byte("7"))
+	if cache.Len() != 3 {
+		t.Errorf("Expected 3 entries after Delete and refill, got %d", cache.Len())
+	}
+}
+
+func TestCacheUnboundedByDefault(t *testing.T) {
+	cache := NewCache(time.Hour)
+	defer cache.Stop()
+	for i := 0; i < 1000; i++ {
+		cache.Add(fmt.Sprintf("key-%d", i), []byte("v"))
+	}
+	if cache.Len() != 1000 {
+		t.Errorf("Expected NewCache to stay unbounded, got %d entries", cache.Len())
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// commandLuck summarizes this session's throws: attempts, catches, success
+// rate and attempts per catch
+func commandLuck(ctx context.Context, cfg *config, args ...[]string) error {
+	attempts := cfg.counters.catchAttempts.Load()
+	catches := cfg.counters.catches.Load()
+	if attempts == 0 {
+		fmt.Println("No Pokeballs thrown yet this session")
+		return nil
+	}
+
+	fmt.Printf("Attempts: %s\n", formatCount(cfg, int(attempts)))
+	fmt.Printf("Catches: %s\n", formatCount(cfg, int(catches)))
+	fmt.Printf("Success rate: %.1f%%\n", float64(catches)*100/float64(attempts))
+	if catches > 0 {
+		fmt.Printf("Attempts per catch: %.2f\n", float64(attempts)/float64(catches))
+	} else {
+		fmt.Println("Attempts per catch: n/a")
+	}
+	return nil
+}
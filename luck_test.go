@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLuck(t *testing.T) {
+	cfg := newTestConfig(t)
+	out := captureOutput(t, func() {
+		processInput("luck", cfg)
+	})
+	if !strings.Contains(out, "No Pokeballs thrown yet") {
+		t.Errorf("expected a zero-attempt message, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		throwBall(context.Background(), cfg, Pokemon{Name: "mewtwo"}, defaultBall, 0) // escapes
+		throwBall(context.Background(), cfg, Pokemon{Name: "mewtwo"}, defaultBall, 0)
+		throwBall(context.Background(), cfg, Pokemon{Name: "mewtwo"}, defaultBall, 0)
+		throwBall(context.Background(), cfg, Pokemon{Name: "pidgey"}, defaultBall, 100) // caught
+		throwBall(context.Background(), cfg, Pokemon{Name: "mewtwo"}, defaultBall, 0)
+		throwBall(context.Background(), cfg, Pokemon{Name: "rattata"}, defaultBall, 100)
+		processInput("luck", cfg)
+	})
+	for _, want := range []string{
+		"Attempts: 6\n",
+		"Catches: 2\n",
+		"Success rate: 33.3%\n",
+		"Attempts per catch: 3.00\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q, got %q", want, out)
+		}
+	}
+}
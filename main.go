@@ -2,30 +2,102 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	neturl "net/url"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	"math/rand"
 
 	"github.com/deoreal/pokedexcli/internal/pokecache"
 )
 
+const defaultBaseURL = "https://pokeapi.co/api/v2"
+
 type config struct {
-	nextURL     *string
-	previousURL *string
-	cache       *pokecache.Cache
-	pokedex     map[string]Pokemon // map of caught pokemon
+	baseURL      string // PokeAPI root, overridable for tests
+	mirrorURL    string // fallback PokeAPI root tried when baseURL fails (-mirror), empty for none
+	nextURL      *string
+	previousURL  *string
+	currentURL   string // the location-area page last shown, which produced nextURL and previousURL
+	mapStarted   bool   // a location-area page has been shown, so a nil nextURL means the last page
+	cache        Cacher
+	client       *http.Client       // makes PokeAPI requests, nil for http.DefaultClient
+	notFound     *pokecache.Cache   // short-lived markers for URLs that returned 404, nil to disable
+	pokedex      map[string]Pokemon // map of caught pokemon
+	quit         bool               // set by the exit command to end the REPL
+	rng          *rand.Rand         // source for catch rolls, injectable for tests
+	events       *eventLog          // optional JSON-lines event stream (-events)
+	endpoints    *endpointStats     // per-endpoint request counts and latency for endpoint-stats
+	counters     sessionCounters    // catch totals for /metrics
+	limiter      rateLimiter        // spaces out network requests (-max-rps), nil for no limit
+	trace        io.Writer          // where -trace writes per-request timings (with -verbose), nil when off
+	flights      flightGroup        // coalesces concurrent requests for the same URL
+	catchLog     *catchLog          // optional append-only log of catch attempts (-catch-log)
+	summary      bool               // print a greppable CATCH line after each throw (-summary)
+	realistic    bool               // use the Gen III+ capture formula (-realistic)
+	interactive  bool               // stdin is a terminal, so commands may prompt
+	lines        <-chan string      // REPL input lines, shared with prompts and batch cancellation
+	inBatch      bool               // a batch's cancel watcher is reading lines, so nothing else may
+	pretty       bool               // format numbers with thousands separators (-pretty)
+	showCacheAge bool               // note "(cached Ns ago)" on output served from cache
+	synergy      bool               // bonus catch chance for Pokémon covering party weaknesses (-synergy)
+	party        []string           // names of caught Pokémon in the battle party
+	lastCaught   string             // the most recent catch, inspected by a bare inspect
+	released     *Pokemon           // the last released Pokémon, until restore brings it back
+	aliases      map[string]string  // user-defined command aliases from the config file
+	history      exploreHistory     // areas visited by explore, for back and forward
+	achievements map[string]bool    // ids of unlocked achievements
+	safari       *safariSession     // the running Safari Zone session, nil outside one
+	inputHistory []string           // command lines entered this session, oldest first
+	kantoSpecies map[string]bool    // species in the Kanto pokedex, fetched once for the Kanto Complete achievement
+	pageSize     int                // location areas per map page (-page-size), 0 for defaultPageSize
+
+	seen           map[string]bool // Pokémon encountered this session via explore or catch
+	congratulated  bool            // the caught-all-seen message has been shown
+	areaNames      []string        // every location-area name, fetched once by random-area
+	offline        bool            // serve requests only from the cache and queue catches for a later sync (-offline)
+	catchQueue     []string        // Pokémon to catch on the next sync, deduplicated
+	queuePath      string          // where catchQueue is persisted, empty to keep it in memory
+	evoHints       bool            // note what a newly caught Pokémon can evolve into (-evo-hints)
+	typeFlavor     bool            // note what a newly caught Pokémon's types are strong against (-flavor)
+	timing         bool            // press Enter in time for a catch bonus, interactive only (-timing)
+	persistence    bool            // failed throws raise the next throw's chance at the same Pokémon (-persistence)
+	failedAttempts map[string]int  // consecutive failed throws per Pokémon, for -persistence
+	unlockedPath   string          // where achievements are persisted, empty to keep them in memory
+	pokedexPath    string          // where the pokedex is saved on exit, empty to keep it in memory
+
+	quiet                 bool          // print only essential results, no flavor text (-quiet)
+	verbose               bool          // print diagnostics such as how long each command took (-verbose)
+	jsonErrors            bool          // print command errors as JSON objects rather than text (-json)
+	maxRetries            int           // extra attempts for a request that failed transiently (-retries)
+	retryBackoff          time.Duration // wait before the first retry, doubled for each one after
+	canonicalizeCacheKeys bool          // sort query parameters before using a URL as a cache key
+	commandTimeout        time.Duration // overall deadline for batch commands such as catch --range, 0 for none
+	pasteWindow           time.Duration // lines arriving closer together than this are a paste to confirm, 0 to run them as typed
 }
 
 type cliCommand struct {
 	name        string
 	description string
-	callback    func(*config, ...[]string) error
+	example     string // a sample invocation for help <command>, defaulting to the bare name
+	callback    func(context.Context, *config, ...[]string) error
+	takesArgs   bool // pass the lowercased words after the command name
+	rawArgs     bool // pass the words after the command name as typed, e.g. for file paths
 }
 
 type LocationAreasResponse struct {
@@ -91,47 +163,271 @@ type LocationAreaResponse struct {
 	} `json:"pokemon_encounters"`
 }
 
-var Commands = map[string]cliCommand{
-	"exit": {
-		name:        "exit",
-		description: "Exit the Pokedex",
-		callback:    commandExit,
-	},
-	"help": {
-		name:        "help",
-		description: "Displays a help message",
-		callback:    commandHelp,
-	},
-	"map": {
-		name:        "map",
-		description: "Displays the names of 20 location areas",
-		callback:    commandMap,
-	},
-	"mapb": {
-		name:        "mapb",
-		description: "Displays the previous 20 location areas",
-		callback:    commandMapB,
-	},
-	"explore": {
-		name:        "explore",
-		description: "Displays the Pokémon in a location area",
-		callback:    commandExplore,
-	},
-	"catch": {
-		name:        "catch",
-		description: "Try to catch a Pokémon by name",
-		callback:    commandCatch,
-	},
-	"inspect": {
-		name:        "inspect",
-		description: "Inspect a caught Pokémon",
-		callback:    commandInspect,
-	},
-	"pokedex": {
-		name:        "pokedex",
-		description: "List all Pokémon you have caught",
-		callback:    commandPokedex,
-	},
+// Commands holds every registered command by name. Add to it with RegisterCommand.
+var Commands = map[string]cliCommand{}
+
+// reservedNames cannot be used as command names because the REPL gives them
+// another meaning
+var reservedNames = map[string]bool{
+	cancelKey: true, // cancels a running batch
+}
+
+// RegisterCommand adds cmd to Commands, rejecting empty, reserved and duplicate
+// names. The built-in aliases are reserved too, so a command never takes over
+// a shorthand users already rely on.
+func RegisterCommand(cmd cliCommand) error {
+	switch {
+	case cmd.name == "" || cmd.name != strings.ToLower(cmd.name) || strings.ContainsAny(cmd.name, " \t"):
+		return fmt.Errorf("invalid command name %q", cmd.name)
+	case reservedNames[cmd.name]:
+		return fmt.Errorf("command name %q is reserved", cmd.name)
+	case builtinAliases[cmd.name] != "":
+		return fmt.Errorf("command name %q is reserved for the %s alias", cmd.name, builtinAliases[cmd.name])
+	case cmd.callback == nil:
+		return fmt.Errorf("command %q has no callback", cmd.name)
+	}
+	if _, ok := Commands[cmd.name]; ok {
+		return fmt.Errorf("command %q is already registered", cmd.name)
+	}
+	Commands[cmd.name] = cmd
+	return nil
+}
+
+func init() {
+	for _, cmd := range []cliCommand{
+		{
+			name:        "exit",
+			description: "Exit the Pokedex",
+			callback:    commandExit,
+		},
+		{
+			name:        "clear",
+			description: "Clears the screen",
+			callback:    commandClear,
+		},
+		{
+			name:        "help",
+			description: "Displays a help message",
+			example:     "help catch",
+			callback:    commandHelp,
+			takesArgs:   true,
+		},
+		{
+			name:        "map",
+			description: "Displays the names of a page of location areas",
+			callback:    commandMap,
+		},
+		{
+			name:        "mapb",
+			description: "Displays the previous page of location areas",
+			callback:    commandMapB,
+		},
+		{
+			name:        "explore",
+			description: "Displays the Pokémon in a location area",
+			example:     "explore pastoria-city-area",
+			callback:    commandExplore,
+			takesArgs:   true,
+		},
+		{
+			name:        "back",
+			description: "Explores the previously visited location area again",
+			callback:    commandBack,
+		},
+		{
+			name:        "forward",
+			description: "Explores the next location area in the explore history",
+			callback:    commandForward,
+		},
+		{
+			name:        "random-area",
+			description: "Explores a random location area",
+			callback:    commandRandomArea,
+		},
+		{
+			name:        "random",
+			description: "Try to catch a random Pokémon from the national dex",
+			example:     "random --ball=ultraball",
+			callback:    commandRandom,
+			takesArgs:   true,
+		},
+		{
+			name:        "catch",
+			description: "Try to catch a Pokémon by name",
+			example:     "catch pikachu --min-chance 20",
+			callback:    commandCatch,
+			takesArgs:   true,
+		},
+		{
+			name:        "inspect",
+			description: "Inspect a caught Pokémon",
+			example:     "inspect pikachu",
+			callback:    commandInspect,
+			takesArgs:   true,
+		},
+		{
+			name:        "release",
+			description: "Releases a caught Pokémon",
+			example:     "release pikachu",
+			callback:    commandRelease,
+			takesArgs:   true,
+		},
+		{
+			name:        "restore",
+			description: "Undoes the last release",
+			callback:    commandRestore,
+		},
+		{
+			name:        "pokedex",
+			description: "List all Pokémon you have caught",
+			example:     "pokedex table --since 2024-01-01",
+			callback:    commandPokedex,
+			takesArgs:   true,
+		},
+		{
+			name:        "whereis",
+			description: "Lists the location areas where a Pokémon can be found",
+			example:     "whereis pikachu",
+			callback:    commandWhereis,
+			takesArgs:   true,
+		},
+		{
+			name:        "regiondex",
+			description: "Shows caught vs. total for a regional pokedex",
+			example:     "regiondex kanto --missing",
+			callback:    commandRegiondex,
+			takesArgs:   true,
+		},
+		{
+			name:        "team",
+			description: "Suggests a team maximizing type coverage",
+			example:     "team suggest",
+			callback:    commandTeam,
+			takesArgs:   true,
+		},
+		{
+			name:        "selftest",
+			description: "Maintainer diagnostics (hidden from help)",
+			callback:    commandSelftest,
+			takesArgs:   true,
+		},
+		{
+			name:        "party",
+			description: "Manage your battle party",
+			example:     "party add pikachu",
+			callback:    commandParty,
+			takesArgs:   true,
+		},
+		{
+			name:        "cache",
+			description: "Export or import the request cache",
+			example:     "cache export cache.json",
+			callback:    commandCache,
+			rawArgs:     true,
+		},
+		{
+			name:        "bst",
+			description: "Ranks caught Pokémon by base stat total",
+			callback:    commandBST,
+		},
+		{
+			name:        "stats",
+			description: "Summarizes your Pokedex",
+			example:     "stats graph",
+			callback:    commandStats,
+			takesArgs:   true,
+		},
+		{
+			name:        "luck",
+			description: "Shows this session's catch success statistics",
+			callback:    commandLuck,
+		},
+		{
+			name:        "achievements",
+			description: "Lists achievements and which are unlocked",
+			callback:    commandAchievements,
+		},
+		{
+			name:        "endpoint-stats",
+			description: "Shows network requests and latency per API endpoint",
+			callback:    commandEndpointStats,
+		},
+		{
+			name:        "safari",
+			description: "Starts or ends a Safari Zone session",
+			example:     "safari start 10",
+			callback:    commandSafari,
+			takesArgs:   true,
+		},
+		{
+			name:        "shuffle",
+			description: "Picks a random caught Pokémon",
+			example:     "shuffle --type fire",
+			callback:    commandShuffle,
+			takesArgs:   true,
+		},
+		{
+			name:        "simulate",
+			description: "Simulates catch throws and writes them to a CSV file",
+			example:     "simulate pikachu 1000 pikachu.csv",
+			callback:    commandSimulate,
+			rawArgs:     true,
+		},
+		{
+			name:        "history",
+			description: "Shows the commands entered this session",
+			example:     "history 20",
+			callback:    commandHistory,
+			takesArgs:   true,
+		},
+		{
+			name:        "alias",
+			description: "Lists command aliases",
+			callback:    commandAlias,
+		},
+		{
+			name:        "set",
+			description: "Changes a runtime setting",
+			example:     "set quiet on",
+			callback:    commandSet,
+			takesArgs:   true,
+		},
+		{
+			name:        "get",
+			description: "Shows a runtime setting",
+			example:     "get quiet",
+			callback:    commandGet,
+			takesArgs:   true,
+		},
+		{
+			name:        "config",
+			description: "Shows all runtime settings",
+			callback:    commandConfig,
+		},
+		{
+			name:        "sync",
+			description: "Replays catches queued while offline",
+			callback:    commandSync,
+		},
+		{
+			name:        "compare",
+			description: "Compares the stats of two caught Pokémon side by side",
+			example:     "compare pikachu raichu",
+			callback:    commandCompare,
+			takesArgs:   true,
+		},
+		{
+			name:        "compare-areas",
+			description: "Shows the Pokémon unique to and shared by two location areas",
+			example:     "compare-areas eterna-forest-area route-205-area",
+			callback:    commandCompareAreas,
+			takesArgs:   true,
+		},
+	} {
+		if err := RegisterCommand(cmd); err != nil {
+			panic(err)
+		}
+	}
 }
 
 // trimMultipleSpaces removes all leading and trailing spaces and reduces all spaces to single spaces
@@ -148,8 +444,54 @@ func trimMultipleSpaces(text string) string {
 	return text
 }
 
+// composedAcute maps a vowel to its precomposed form with an acute accent
+var composedAcute = map[rune]rune{
+	'a': 'á', 'e': 'é', 'i': 'í', 'o': 'ó', 'u': 'ú',
+	'A': 'Á', 'E': 'É', 'I': 'Í', 'O': 'Ó', 'U': 'Ú',
+}
+
+// composeAccents folds a vowel followed by a combining acute accent (U+0301)
+// into its precomposed (NFC) rune, so "Flabe\u0301be\u0301" and "Flabébé"
+// produce the same name. This is the only combining mark used in Pokémon names.
+func composeAccents(text string) string {
+	if !strings.ContainsRune(text, '\u0301') {
+		return text
+	}
+	runes := []rune(text)
+	out := make([]rune, 0, len(runes))
+	for _, r := range runes {
+		if r == '\u0301' && len(out) > 0 {
+			if composed, ok := composedAcute[out[len(out)-1]]; ok {
+				out[len(out)-1] = composed
+				continue
+			}
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
+
+// stripControl removes byte order marks and non-printable control characters,
+// keeping ordinary whitespace (space, tab, newline, carriage return)
+func stripControl(text string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\uFEFF':
+			return -1
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			return r
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, text)
+}
+
 func cleanInput(text string) []string {
 	var res []string
+	text = stripControl(text)
+	text = composeAccents(text)
+	// strings.ToLower is Unicode-aware, so "É" becomes "é" and symbols like "♀" pass through
 	text = strings.ToLower(text)
 	text = strings.TrimSpace(text)
 	text = trimMultipleSpaces(text)
@@ -169,344 +511,1115 @@ func processInput(input string, cfg *config) {
 		return
 	}
 
-	commandName := in[0]
+	commandName := resolveCommand(cfg, in[0])
 	if cmd, ok := Commands[commandName]; !ok {
-		fmt.Println("Unknown command")
+		if cfg.jsonErrors {
+			printJSONError(commandName, errors.New("unknown command"))
+		} else {
+			fmt.Println("Unknown command")
+		}
 	} else {
 		var err error
-		// Pass arguments for commands that expect them (all except help, exit, map, mapb)
-		switch commandName {
-		case "explore", "catch", "inspect":
-			err = cmd.callback(cfg, in[1:])
+		start := time.Now()
+		// Ctrl-C cancels the running command's requests instead of
+		// killing the REPL; at the prompt it exits as usual
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		switch {
+		case cmd.rawArgs:
+			err = cmd.callback(ctx, cfg, strings.Fields(stripControl(input))[1:])
+		case cmd.takesArgs:
+			err = cmd.callback(ctx, cfg, in[1:])
 		default:
-			err = cmd.callback(cfg)
+			err = cmd.callback(ctx, cfg)
 		}
-		if err != nil {
+		stop()
+		verbosef(cfg, "(%s took %dms)\n", cmd.name, time.Since(start).Milliseconds())
+		var userErr *userError
+		switch {
+		case err == nil:
+		case cfg.jsonErrors:
+			printJSONError(cmd.name, err)
+		case errors.As(err, &userErr):
+			fmt.Println(err)
+		default:
 			fmt.Println("Error occurred:", err)
 		}
+		var offErr *offlineError
+		if errors.As(err, &offErr) {
+			verbosef(cfg, "cause: %v\n", offErr.err)
+		}
+	}
+}
+
+// printJSONError prints a failed command as {"error":"...","command":"..."} for -json
+func printJSONError(command string, err error) {
+	data, _ := json.Marshal(struct {
+		Error   string `json:"error"`
+		Command string `json:"command"`
+	}{err.Error(), command})
+	fmt.Println(string(data))
+}
+
+// cacheKey returns the key url is cached under. With cfg.canonicalizeCacheKeys
+// set, query parameters are sorted so equivalent URLs share an entry.
+func cacheKey(cfg *config, url string) string {
+	if !cfg.canonicalizeCacheKeys {
+		return url
+	}
+	u, err := neturl.Parse(url)
+	if err != nil || u.RawQuery == "" {
+		return url
+	}
+	u.RawQuery = u.Query().Encode()
+	return u.String()
+}
+
+// userError is a command failure worded for the user, such as a usage message
+// or a name that wasn't found. processInput prints it as is, or as JSON with -json.
+type userError struct {
+	msg string
+}
+
+func (e *userError) Error() string {
+	return e.msg
+}
+
+// userErrorf formats a userError
+func userErrorf(format string, a ...any) error {
+	return &userError{msg: fmt.Sprintf(format, a...)}
+}
+
+// statusError reports a non-200 response from PokeAPI
+type statusError struct {
+	code int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("bad status code: %d", e.code)
+}
+
+// isNotFound reports whether err is, or wraps, a 404 response
+func isNotFound(err error) bool {
+	var statusErr *statusError
+	return errors.As(err, &statusErr) && statusErr.code == http.StatusNotFound
+}
+
+// ErrOffline is wrapped by errors for requests that never reached PokeAPI
+var ErrOffline = errors.New("can't reach PokeAPI")
+
+// offlineError reports a connection-level failure with a friendly message,
+// while keeping the underlying error available through errors.As
+type offlineError struct {
+	err error
+}
+
+func (e *offlineError) Error() string {
+	return "can't reach PokeAPI, check your internet connection or start with -offline"
+}
+
+func (e *offlineError) Unwrap() []error {
+	return []error{ErrOffline, e.err}
+}
+
+// notCachedError reports a cache miss while offline, when no request is made
+type notCachedError struct {
+	url string
+}
+
+func (e *notCachedError) Error() string {
+	return fmt.Sprintf("offline: %s not cached", e.url)
+}
+
+func (e *notCachedError) Unwrap() error {
+	return ErrOffline
+}
+
+// isConnectionError reports whether err is a DNS, dial or connection failure
+// rather than a problem with a response
+func isConnectionError(err error) bool {
+	var opErr *net.OpError
+	var dnsErr *net.DNSError
+	return errors.As(err, &opErr) || errors.As(err, &dnsErr)
+}
+
+// rngSeed returns seed, or a time-based seed when it is 0
+func rngSeed(seed int64) int64 {
+	if seed == 0 {
+		return time.Now().UnixNano()
 	}
+	return seed
 }
 
-// makeRequest handles HTTP requests with caching
-func makeRequest(url string, cache *pokecache.Cache) ([]byte, error) {
+// defaultRetryBackoff is the wait before the first retry of a failed request
+const defaultRetryBackoff = 250 * time.Millisecond
+
+// defaultCacheTTL is how long PokeAPI responses are cached; raise it with
+// -cache-ttl to keep responses on disk across sessions
+const defaultCacheTTL = 5 * time.Second
+
+// defaultHTTPTimeout bounds each PokeAPI request, so a hung connection can't freeze the REPL
+const defaultHTTPTimeout = 10 * time.Second
+
+// NewClient returns an HTTP client that gives up on a request after timeout; 0 means no limit
+func NewClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout}
+}
+
+// makeRequest handles HTTP requests with caching. Cancelling ctx abandons
+// the request and its retries.
+func makeRequest(ctx context.Context, cfg *config, url string) ([]byte, error) {
+	body, _, _, err := makeRequestWithAge(ctx, cfg, url)
+	return body, err
+}
+
+// makeRequestWithAge is makeRequest that also reports whether the body came
+// from the cache and, if so, how old the cached entry is
+func makeRequestWithAge(ctx context.Context, cfg *config, url string) ([]byte, time.Duration, bool, error) {
+	key := cacheKey(cfg, url)
+
 	// Check cache first
-	if data, found := cache.Get(url); found {
-		return data, nil
+	if data, age, found := cfg.cache.GetWithAge(key); found {
+		cfg.events.emit(Event{Type: eventCacheHit, URL: url})
+		return data, age, true, nil
+	}
+	if cfg.notFound != nil {
+		if _, found := cfg.notFound.Get(key); found {
+			cfg.events.emit(Event{Type: eventCacheHit, URL: url})
+			return nil, 0, false, &statusError{code: http.StatusNotFound}
+		}
+	}
+
+	if cfg.offline {
+		return nil, 0, false, &notCachedError{url: url}
+	}
+
+	// Concurrent requests for the same URL share one network call
+	body, err := cfg.flights.do(key, func() ([]byte, error) {
+		return fetchURL(ctx, cfg, key, url)
+	})
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return body, 0, false, nil
+}
+
+// fetchURL makes the network request for url and caches the body under key.
+// If the request still fails after its retries, for any reason but a 404,
+// and a mirror is configured, it is tried once more against the mirror.
+func fetchURL(ctx context.Context, cfg *config, key, url string) ([]byte, error) {
+	body, err := fetchWithRetry(ctx, cfg, key, url)
+	if err != nil && !isNotFound(err) && ctx.Err() == nil && cfg.mirrorURL != "" && strings.HasPrefix(url, cfg.baseURL) {
+		if tracing(cfg) {
+			fmt.Fprintf(cfg.trace, "mirror %s after %v\n", cfg.mirrorURL, err)
+		} else {
+			verbosef(cfg, "PokeAPI request failed, trying mirror %s\n", cfg.mirrorURL)
+		}
+		body, err = fetchFrom(ctx, cfg, key, url, cfg.mirrorURL+strings.TrimPrefix(url, cfg.baseURL))
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// Make HTTP request
-	resp, err := http.Get(url)
+	// Add to cache under the logical URL, so it doesn't matter which host served it
+	cfg.cache.Add(key, body)
+
+	return body, nil
+}
+
+// fetchWithRetry is fetchFrom for url itself, retried up to cfg.maxRetries
+// times with exponential backoff while failures look transient
+func fetchWithRetry(ctx context.Context, cfg *config, key, url string) ([]byte, error) {
+	backoff := cfg.retryBackoff
+	for attempt := 0; ; attempt++ {
+		body, err := fetchFrom(ctx, cfg, key, url, url)
+		if err == nil || attempt >= cfg.maxRetries || !isTransient(err) {
+			return body, err
+		}
+		if tracing(cfg) {
+			fmt.Fprintf(cfg.trace, "retry %s in %s after %v\n", url, backoff, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("request cancelled: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isTransient reports whether a failed request is worth retrying: network
+// errors and 5xx responses are, while 4xx responses such as 404 and
+// cancellations are final
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.code >= 500
+	}
+	return true
+}
+
+// fetchFrom requests target, which is url itself or its mirror equivalent
+func fetchFrom(ctx context.Context, cfg *config, key, url, target string) ([]byte, error) {
+	if err := waitForRateLimit(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("request cancelled: %w", err)
+	}
+	cfg.events.emit(Event{Type: eventRequest, URL: target})
+	start := time.Now()
+	defer func() {
+		cfg.endpoints.record(endpointPattern(cfg.baseURL, url), time.Since(start))
+	}()
+	resp, err := httpGet(ctx, cfg, target)
 	if err != nil {
+		// A cancelled dial looks like a connection error, so check ctx first
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("request cancelled: %w", ctx.Err())
+		}
+		if isConnectionError(err) {
+			return nil, &offlineError{err: err}
+		}
 		return nil, fmt.Errorf("error making request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	// Only a 404 is a lasting answer; 5xx and other errors may be transient
+	if resp.StatusCode == http.StatusNotFound && cfg.notFound != nil {
+		cfg.notFound.Add(key, nil)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("bad status code: %d", resp.StatusCode)
+		return nil, &statusError{code: resp.StatusCode}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("error reading response body: %w", err)
 	}
+	return body, nil
+}
 
-	// Add to cache
-	cache.Add(url, body)
+// getJSON fetches url through the cache and decodes the body into a T
+func getJSON[T any](ctx context.Context, cfg *config, url string) (T, error) {
+	v, _, _, err := getJSONWithAge[T](ctx, cfg, url)
+	return v, err
+}
 
-	return body, nil
+// getJSONWithAge is getJSON that also reports whether the body came from the
+// cache and how old it is. A body that fails to decode is dropped from the
+// cache, and if it was a cached copy (say, truncated on disk) it is fetched
+// fresh once before giving up.
+func getJSONWithAge[T any](ctx context.Context, cfg *config, url string) (T, time.Duration, bool, error) {
+	var v T
+	key := cacheKey(cfg, url)
+	typeName := fmt.Sprintf("%T", v)
+	decoded := findDecoded(cfg.cache)
+	body, age, hit, err := makeRequestWithAge(ctx, cfg, url)
+	if err != nil {
+		return v, 0, false, err
+	}
+	if hit {
+		if val, ok := decoded.decoded(key, typeName, body); ok {
+			return val.(T), age, hit, nil
+		}
+	}
+	if err := json.Unmarshal(body, &v); err != nil {
+		cfg.cache.Delete(key)
+		if !hit {
+			return v, 0, false, fmt.Errorf("error unmarshaling JSON: %w", err)
+		}
+
+		v = *new(T)
+		body, err = makeRequest(ctx, cfg, url)
+		if err != nil {
+			return v, 0, false, err
+		}
+		if err := json.Unmarshal(body, &v); err != nil {
+			cfg.cache.Delete(key)
+			return v, 0, false, fmt.Errorf("error unmarshaling JSON: %w", err)
+		}
+		age, hit = 0, false
+	}
+	decoded.storeDecoded(key, typeName, body, v)
+	return v, age, hit, nil
+}
+
+// cacheAgeSuffix returns " (cached Ns ago)" for cache hits when -show-cache-age is set
+func cacheAgeSuffix(cfg *config, age time.Duration, hit bool) string {
+	if !cfg.showCacheAge || !hit {
+		return ""
+	}
+	return fmt.Sprintf(" (cached %ds ago)", int(age.Seconds()))
 }
 
 func main() {
-	// Initialize cache with 5 second interval
-	cache := pokecache.NewCache(5 * time.Second)
+	eventsPath := flag.String("events", "", "append a JSON line per event to this file")
+	summary := flag.Bool("summary", false, "print a greppable summary line after each catch")
+	canonicalKeys := flag.Bool("canonical-cache-keys", false, "ignore query parameter order when caching requests")
+	realistic := flag.Bool("realistic", false, "compute catch chance with the Gen III+ capture formula")
+	cacheMaxBytes := flag.Int("cache-max-bytes", 0, "evict the oldest cache entries beyond this many bytes (0 = unbounded)")
+	cacheJitter := flag.Float64("cache-jitter", 0, "spread cache expirations by this fraction of the TTL, e.g. 0.1 for ±10%")
+	pretty := flag.Bool("pretty", false, "format large numbers with thousands separators")
+	showCacheAge := flag.Bool("show-cache-age", false, "note when output was served from the cache and how old it is")
+	synergy := flag.Bool("synergy", false, "give a catch bonus to Pokémon that cover your party's weaknesses")
+	logCatches := flag.Bool("catch-log", false, "append every catch attempt to ~/.pokedexcli/catches.log")
+	commandTimeout := flag.Duration("timeout-per-command", 10*time.Minute, "abort batch commands such as catch --range after this long (0 disables)")
+	persistence := flag.Bool("persistence", false, "raise the catch chance after each failed throw at the same Pokémon")
+	timing := flag.Bool("timing", false, "time each throw by pressing Enter for a catch bonus (interactive only)")
+	negativeTTL := flag.Duration("negative-cache-ttl", 30*time.Second, "remember 404 responses for this long (0 disables)")
+	offline := flag.Bool("offline", false, "serve requests only from the cache, including responses kept on disk within -cache-ttl, and queue catches for a later sync")
+	evoHints := flag.Bool("evo-hints", false, "after a catch, note what the Pokémon can still evolve into")
+	typeFlavor := flag.Bool("flavor", false, "after a catch, note what the Pokémon's types are strong against")
+	maxRPS := flag.Float64("max-rps", 0, "limit network requests to this many per second (0 disables)")
+	trace := flag.Bool("trace", false, "with -verbose, print DNS, connect, TLS and first-byte timings for each request to stderr")
+	dumpCacheOnExit := flag.Bool("dump-cache-on-exit", false, "on exit, print every cached URL with its age and size to stderr")
+	mirror := flag.String("mirror", "", "fallback PokeAPI base URL to try when a request to the primary fails")
+	cacheMetrics := flag.Bool("cache-metrics", false, "count cache writes, and show them with hits and misses in cache stats")
+	logCache := flag.Bool("log-cache", false, "log every cache lookup and write to stderr")
+	verbose := flag.Bool("verbose", false, "print diagnostics such as how long each command took")
+	jsonErrors := flag.Bool("json", false, "print command errors as JSON objects for scripts")
+	httpTimeout := flag.Duration("http-timeout", defaultHTTPTimeout, "give up on a PokeAPI request after this long (0 disables)")
+	retries := flag.Int("retries", 2, "retry a request failing with a network error or 5xx this many times")
+	seed := flag.Int64("seed", 0, "seed for catch rolls, to replay a session exactly (0 picks a random seed)")
+	pageSize := defaultPageSize
+	flag.Func("page-size", fmt.Sprintf("list this many location areas per map page, 1 to %d (default %d)", maxPageSize, defaultPageSize), func(s string) error {
+		n, err := parsePageSize(s)
+		if err != nil {
+			return err
+		}
+		pageSize = n
+		return nil
+	})
+	cacheDir := flag.String("cache-dir", "", "keep fetched PokeAPI responses here between sessions (default ~/.pokedexcli/cache, \"off\" for memory only)")
+	cacheTTL := flag.Duration("cache-ttl", defaultCacheTTL, "how long fetched PokeAPI responses stay cached, in memory and on disk")
+	quiet := flag.Bool("quiet", false, "suppress flavor text and print only essential results")
+	serveAddr := flag.String("serve", "", "serve the pokedex over HTTP on this address instead of the REPL")
+	flag.Parse()
+
+	// A zero byte limit means unbounded
+	cache := pokecache.NewCacheWithMaxBytes(*cacheTTL, *cacheMaxBytes)
+	if *cacheJitter > 0 {
+		cache.SetJitter(*cacheJitter, rand.New(rand.NewSource(time.Now().UnixNano())))
+	}
+
+	// Decoded values sit closest to the bytes, so every decorator sees each lookup
+	var store Cacher = newDecodedCache(cache)
+	if *cacheMetrics {
+		store = newMeteredCache(store)
+	}
+	if *logCache {
+		store = newLoggingCache(store, os.Stderr)
+	}
+
+	// Misspelled names 404; remember them briefly so repeats skip the network
+	var notFound *pokecache.Cache
+	if *negativeTTL > 0 {
+		notFound = pokecache.NewCache(*negativeTTL)
+	}
 
 	cfg := &config{
-		cache:   cache,
-		pokedex: make(map[string]Pokemon),
+		baseURL:      defaultBaseURL,
+		mirrorURL:    strings.TrimSuffix(*mirror, "/"),
+		cache:        store,
+		client:       NewClient(*httpTimeout),
+		notFound:     notFound,
+		endpoints:    newEndpointStats(),
+		pokedex:      make(map[string]Pokemon),
+		rng:          rand.New(rand.NewSource(rngSeed(*seed))),
+		summary:      *summary,
+		realistic:    *realistic,
+		interactive:  isTerminal(os.Stdin),
+		pretty:       *pretty,
+		showCacheAge: *showCacheAge,
+		synergy:      *synergy,
+		persistence:  *persistence,
+		timing:       *timing,
+		offline:      *offline,
+		evoHints:     *evoHints,
+		typeFlavor:   *typeFlavor,
+		pageSize:     pageSize,
+
+		quiet:                 *quiet,
+		verbose:               *verbose,
+		jsonErrors:            *jsonErrors,
+		maxRetries:            *retries,
+		retryBackoff:          defaultRetryBackoff,
+		canonicalizeCacheKeys: *canonicalKeys,
+		commandTimeout:        *commandTimeout,
+		pasteWindow:           defaultPasteWindow,
 	}
 
-	scanner := bufio.NewScanner(os.Stdin)
-	for {
+	if *eventsPath != "" {
+		events, err := openEventLog(*eventsPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer events.Close()
+		cfg.events = events
+	}
+
+	if *trace {
+		cfg.trace = os.Stderr
+	}
+	if *maxRPS > 0 {
+		cfg.limiter = newIntervalLimiter(*maxRPS)
+	}
+
+	// Without a home directory everything still works, it just isn't saved
+	dir, err := dataDir()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Keeping all data in memory only: %v\n", err)
+	}
+
+	if *cacheDir == "" && dir != "" {
+		*cacheDir = filepath.Join(dir, "cache")
+	}
+	if *cacheDir != "" && *cacheDir != "off" {
+		if err := cache.SetDir(*cacheDir); err != nil {
+			fmt.Fprintf(os.Stderr, "Keeping the cache in memory only: %v\n", err)
+		}
+	}
+
+	if dir != "" {
+		loadSavedData(cfg, dir)
+	}
+
+	if *logCatches {
+		if dir == "" {
+			fmt.Fprintln(os.Stderr, "Not logging catches: no home directory")
+		} else {
+			catches, err := openCatchLog(filepath.Join(dir, "catches.log"))
+			if err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			defer catches.Close()
+			cfg.catchLog = catches
+		}
+	}
+
+	if *serveAddr != "" {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		err := runServer(ctx, cfg, *serveAddr)
+		stop()
+		cache.Stop()
+		if notFound != nil {
+			notFound.Stop()
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	runREPL(os.Stdin, cfg)
+
+	// End of input (Ctrl-D or a finished script) skips exit, so save here too
+	if !cfg.quit {
+		persistPokedex(cfg)
+	}
+
+	if *dumpCacheOnExit && !cfg.quiet {
+		if err := dumpCache(os.Stderr, cache, time.Now()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}
+	cache.Stop()
+	if notFound != nil {
+		notFound.Stop()
+	}
+	if !cfg.quit {
+		fmt.Println("Ciao")
+	}
+}
+
+// loadSavedData loads the pokedex, catch queue, achievements and aliases saved
+// under dir. A file that can't be read is reported and left alone: that part
+// starts empty and stays in memory, so a corrupt file is never overwritten.
+func loadSavedData(cfg *config, dir string) {
+	var err error
+	path := filepath.Join(dir, "pokedex.json")
+	if cfg.pokedex, err = loadPokedex(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Starting with an empty pokedex that won't be saved: %v\n", err)
+		cfg.pokedex = make(map[string]Pokemon)
+	} else {
+		cfg.pokedexPath = path
+	}
+
+	path = filepath.Join(dir, "catch-queue.json")
+	if cfg.catchQueue, err = loadQueue(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Starting with an empty catch queue that won't be saved: %v\n", err)
+		cfg.catchQueue = nil
+	} else {
+		cfg.queuePath = path
+	}
+
+	path = filepath.Join(dir, "achievements.json")
+	if cfg.achievements, err = loadAchievements(path); err != nil {
+		fmt.Fprintf(os.Stderr, "Starting with no achievements, and not saving them: %v\n", err)
+		cfg.achievements = make(map[string]bool)
+	} else {
+		cfg.unlockedPath = path
+	}
+
+	userCfg, err := loadUserConfig(filepath.Join(dir, "config.json"))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ignoring config.json: %v\n", err)
+	}
+	aliases, aliasErrs := validAliases(userCfg.Aliases)
+	for _, err := range aliasErrs {
+		fmt.Fprintf(os.Stderr, "Ignoring %v\n", err)
+	}
+	cfg.aliases = aliases
+}
+
+// isTerminal reports whether f is an interactive terminal rather than a pipe or file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// runREPL reads commands from r until EOF or until a command sets cfg.quit
+func runREPL(r io.Reader, cfg *config) {
+	done := make(chan struct{})
+	defer close(done)
+	cfg.lines = readLines(r, done)
+
+	for !cfg.quit {
 		fmt.Print("Pokedex > ")
 
-		if !scanner.Scan() {
+		line, ok := <-cfg.lines
+		if !ok {
 			break
 		}
-		input := strings.TrimSpace(scanner.Text())
+		// A pasted block is previewed before it runs, so a mistake midway
+		// doesn't fire off every command after it
+		if cfg.interactive && cfg.pasteWindow > 0 {
+			batch := collectPaste(line, cfg.lines, cfg.pasteWindow)
+			if len(batch) > 1 && !confirmPaste(cfg, batch) {
+				fmt.Println("Skipped pasted commands.")
+				continue
+			}
+			for _, input := range batch {
+				if cfg.quit {
+					break
+				}
+				input, ok := expandCompletion(cfg, input)
+				if !ok {
+					continue
+				}
+				recordInput(cfg, input)
+				processInput(input, cfg)
+			}
+			continue
+		}
+
+		line, ok = expandCompletion(cfg, line)
+		if !ok {
+			continue
+		}
+		input := strings.TrimSpace(line)
 
 		if input == "" {
 			continue
 		}
 
+		recordInput(cfg, input)
 		processInput(input, cfg)
+	}
+}
 
+// readLines pumps lines from r into the returned channel on a single goroutine,
+// so the REPL, prompts and batch cancellation can all share one input stream.
+// The channel is closed at EOF; closing done stops the pump early.
+func readLines(r io.Reader, done <-chan struct{}) <-chan string {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-done:
+				return
+			}
+		}
 		if err := scanner.Err(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error reading input: %v\n", err)
 		}
-	}
+	}()
+	return lines
+}
 
-	fmt.Println("Ciao")
+// helpLines are the usage lines help prints, as "<usage>: <description>"
+var helpLines = []string{
+	"help [command]: Displays a help message, or detailed usage for one command",
+	"map: Displays the names of a page of location areas (see -page-size)",
+	"mapb: Displays the previous page of location areas",
+	"explore <location-area-name> [--raw-order] [--by-rarity] [--json]: Displays the Pokémon in a location area",
+	"back: Explores the previously visited location area again",
+	"forward: Explores the next location area after going back",
+	"random-area: Explores a random location area",
+	"random [--min-chance N] [--ball=<ball>]: Try to catch a random Pokémon from the national dex",
+	"catch <pokemon-name> [--min-chance N] [--ball=greatball|ultraball|masterball]: Try to catch a Pokémon by name",
+	"catch --range <start> <end>: Try to catch every Pokémon in a national dex range",
+	"catch --from <location-area-name> <pokemon-name>: Try to catch a Pokémon found in an area; rare encounters are a little harder",
+	"catch --area <location-area-name> [--include-caught]: Try to catch every uncaught Pokémon in a location area",
+	"inspect [pokemon-name]: Inspect a caught Pokémon, by default the last one caught",
+	"release <pokemon-name>: Releases a caught Pokémon",
+	"restore: Undoes the last release",
+	"safari start <count> | safari end: Catch with a limited number of Safari Balls, which catch more easily (one Pokémon at a time)",
+	"shuffle [--type <type>]: Picks a random caught Pokémon",
+	"pokedex [table] [--json] [--since YYYY-MM-DD]: List all Pokémon you have caught",
+	"simulate <pokemon-name> <n> <file>: Simulates n throws without catching and writes them to a CSV file",
+	"compare <pokemon> <pokemon>: Compares the stats of two caught Pokémon side by side",
+	"compare-areas <area> <area>: Shows the Pokémon unique to and shared by two location areas",
+	"bst: Ranks caught Pokémon by base stat total",
+	"stats [graph]: Summarizes your Pokedex, or charts it by base experience",
+	"whereis <pokemon-name>: Lists the location areas where a Pokémon can be found",
+	"regiondex <region> [--missing]: Shows caught vs. total for a regional pokedex",
+	"team suggest: Suggests a team of caught Pokémon maximizing type coverage",
+	"party [add|remove <pokemon-name>]: Manage your battle party",
+	"luck: Shows this session's catch success statistics",
+	"achievements: Lists achievements and which are unlocked (saved to ~/.pokedexcli/achievements.json, apart from the pokedex)",
+	"endpoint-stats: Shows network requests and latency per API endpoint",
+	"cache: Shows request cache hits, misses and entries",
+	"cache stats: Show request cache usage",
+	"cache export|import <file>: Export or import the request cache",
+	"sync: Replays catches queued while offline",
+	"history [count]: Shows the last commands entered this session, 10 by default",
+	"alias: Lists command aliases, including those from config.json",
+	"set <key> <value>: Changes a runtime setting",
+	"get <key>: Shows a runtime setting",
+	"config: Shows all runtime settings",
+	"clear: Clears the screen",
+	"exit: Exit the Pokedex",
 }
 
-func commandHelp(cfg *config, args ...[]string) error {
+func commandHelp(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) > 0 && len(args[0]) > 0 {
+		return commandHelpFor(cfg, args[0][0])
+	}
+
 	fmt.Println()
 	fmt.Println("Welcome to the Pokedex!")
 	fmt.Println("Usage:")
 	fmt.Println()
-	fmt.Println("help: Displays a help message")
-	fmt.Println("map: Displays the names of 20 location areas")
-	fmt.Println("mapb: Displays the previous 20 location areas")
-	fmt.Println("explore <location-area-name>: Displays the Pokémon in a location area")
-	fmt.Println("catch <pokemon-name>: Try to catch a Pokémon by name")
-	fmt.Println("inspect <pokemon-name>: Inspect a caught Pokémon")
-	fmt.Println("pokedex: List all Pokémon you have caught")
-	fmt.Println("exit: Exit the Pokedex")
+	for _, line := range helpLines {
+		fmt.Println(line)
+	}
+	fmt.Println()
+	printAliases(cfg)
 	fmt.Println()
 	return nil
 }
 
-func commandExplore(cfg *config, args ...[]string) error {
-	if len(args) == 0 || len(args[0]) == 0 {
-		fmt.Println("You must provide a location area name")
+// commandHelpFor prints the description, usage lines and an example for one command
+func commandHelpFor(cfg *config, name string) error {
+	cmd, ok := Commands[resolveCommand(cfg, name)]
+	var usages []string
+	if ok {
+		for _, line := range helpLines {
+			usage, _, _ := strings.Cut(line, ": ")
+			if usage == cmd.name || strings.HasPrefix(usage, cmd.name+" ") {
+				usages = append(usages, usage)
+			}
+		}
+	}
+	// Commands without a help line, like selftest, are hidden on purpose
+	if len(usages) == 0 {
+		fmt.Printf("No help available for %s.\n", name)
 		return nil
 	}
 
-	locationAreaName := args[0][0]
-	url := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%s", locationAreaName)
+	example := cmd.example
+	if example == "" {
+		example = cmd.name
+	}
+	fmt.Printf("%s: %s\n", cmd.name, cmd.description)
+	fmt.Println("Usage:")
+	for _, usage := range usages {
+		fmt.Printf("  %s\n", usage)
+	}
+	fmt.Printf("Example: %s\n", example)
+	return nil
+}
 
-	// Use cached request
-	body, err := makeRequest(url, cfg.cache)
-	if err != nil {
-		return fmt.Errorf("failed to fetch location area data: %w", err)
+func commandExplore(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) == 0 {
+		return userErrorf("You must provide a location area name")
+	}
+
+	rest, rawOrder := popFlag(args[0], "raw-order")
+	rest, byRarity := popFlag(rest, "by-rarity")
+	rest, asJSON := popFlag(rest, "json")
+	if len(rest) == 0 {
+		return userErrorf("You must provide a location area name")
+	}
+
+	if asJSON {
+		area, err := fetchLocationArea(ctx, cfg, rest[0])
+		if err != nil {
+			return err
+		}
+		names := encounterNames(area, !rawOrder)
+		markSeen(cfg, names...)
+		cfg.history.visit(rest[0])
+		return writeJSON(os.Stdout, names)
 	}
 
-	var locationAreaResp LocationAreaResponse
-	err = json.Unmarshal(body, &locationAreaResp)
+	if err := showArea(ctx, cfg, rest[0], rawOrder, byRarity); err != nil {
+		return err
+	}
+	cfg.history.visit(rest[0])
+	return nil
+}
+
+// showArea prints the Pokémon found in a location area
+func showArea(ctx context.Context, cfg *config, locationAreaName string, rawOrder, byRarity bool) error {
+	locationAreaResp, age, hit, err := fetchLocationAreaWithAge(ctx, cfg, locationAreaName)
 	if err != nil {
-		return fmt.Errorf("error unmarshaling JSON: %w", err)
+		return err
 	}
 
-	fmt.Printf("\nExploring %s...\n", locationAreaName)
-	fmt.Println("Found Pokémon:")
+	flavorf(cfg, "\nExploring %s...%s\n", locationAreaName, cacheAgeSuffix(cfg, age, hit))
+	flavorf(cfg, "Found Pokémon:\n")
 
-	if len(locationAreaResp.PokemonEncounters) == 0 {
-		fmt.Println(" - No Pokémon found in this area")
-	} else {
-		for _, encounter := range locationAreaResp.PokemonEncounters {
-			fmt.Printf(" - %s\n", encounter.Pokemon.Name)
+	names := encounterNames(locationAreaResp, !rawOrder)
+	markSeen(cfg, names...)
+	if len(names) == 0 {
+		flavorf(cfg, " - No Pokémon found in this area\n")
+	}
+	switch {
+	case byRarity:
+		printByRarity(locationAreaResp, names)
+	case cfg.quiet:
+		for _, name := range names {
+			fmt.Println(name)
+		}
+	default:
+		for _, name := range names {
+			fmt.Printf(" - %s\n", name)
 		}
 	}
-	fmt.Println()
+	flavorf(cfg, "\n")
 
 	return nil
 }
 
-func commandExit(cfg *config, args ...[]string) error {
-	cfg.cache.Stop()
-	fmt.Println("Closing the Pokedex... Goodbye!")
-	os.Exit(0)
-	return nil // This line won't be reached due to os.Exit(0)
+// fetchLocationArea fetches and decodes a single location area by name
+func fetchLocationArea(ctx context.Context, cfg *config, name string) (LocationAreaResponse, error) {
+	locationAreaResp, _, _, err := fetchLocationAreaWithAge(ctx, cfg, name)
+	return locationAreaResp, err
 }
 
-func commandMap(cfg *config, args ...[]string) error {
-	url := "https://pokeapi.co/api/v2/location-area"
-
-	// If we have a next URL from previous pagination, use it
-	if cfg.nextURL != nil {
-		url = *cfg.nextURL
-	}
+// fetchLocationAreaWithAge is fetchLocationArea that also reports cache freshness
+func fetchLocationAreaWithAge(ctx context.Context, cfg *config, name string) (LocationAreaResponse, time.Duration, bool, error) {
+	url := fmt.Sprintf("%s/location-area/%s", cfg.baseURL, neturl.PathEscape(name))
 
 	// Use cached request
-	body, err := makeRequest(url, cfg.cache)
+	locationAreaResp, age, hit, err := getJSONWithAge[LocationAreaResponse](ctx, cfg, url)
 	if err != nil {
-		return err
+		return locationAreaResp, 0, false, fmt.Errorf("failed to fetch location area data: %w", err)
 	}
+	return locationAreaResp, age, hit, nil
+}
 
-	var locationAreasResp LocationAreasResponse
-	err = json.Unmarshal(body, &locationAreasResp)
-	if err != nil {
-		return fmt.Errorf("error unmarshaling JSON: %w", err)
+// encounterNames returns the Pokémon names in an area with duplicates removed,
+// sorted alphabetically unless sorted is false (API order is kept instead)
+func encounterNames(area LocationAreaResponse, sorted bool) []string {
+	seen := make(map[string]bool, len(area.PokemonEncounters))
+	names := make([]string, 0, len(area.PokemonEncounters))
+	for _, encounter := range area.PokemonEncounters {
+		name := encounter.Pokemon.Name
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
 	}
-
-	// Update config with new pagination URLs
-	cfg.nextURL = locationAreasResp.Next
-	cfg.previousURL = locationAreasResp.Previous
-
-	// Display the location areas
-	fmt.Println()
-	for _, result := range locationAreasResp.Results {
-		fmt.Println(result.Name)
+	if sorted {
+		sort.Strings(names)
 	}
-	fmt.Println()
+	return names
+}
 
+// commandExit signals the REPL to stop; cleanup happens in main after the loop
+func commandExit(ctx context.Context, cfg *config, args ...[]string) error {
+	// A failed save is reported but must not trap the user in the REPL
+	persistPokedex(cfg)
+	fmt.Println("Closing the Pokedex... Goodbye!")
+	cfg.events.emit(Event{Type: eventExit})
+	cfg.quit = true
 	return nil
 }
 
-// Pokemon struct for storing caught Pokemon
-type Pokemon struct {
-	Name           string   `json:"name"`
-	BaseExperience int      `json:"base_experience"`
-	Height         int      `json:"height"`
-	Weight         int      `json:"weight"`
-	Stats          []Stat   `json:"stats"`
-	Types          []string `json:"types"`
-}
+// clearLines is how many blank lines clear prints when escape codes can't be used
+const clearLines = 3
 
-type Stat struct {
-	Name  string `json:"name"`
-	Value int    `json:"value"`
-}
-
-func commandCatch(cfg *config, args ...[]string) error {
-	if len(args) == 0 || len(args[0]) == 0 {
-		fmt.Println("You must provide a Pokémon name")
+// commandClear clears the terminal. Without a terminal, escape codes would end
+// up as garbage in a pipe or file, so it prints a few blank lines instead.
+func commandClear(ctx context.Context, cfg *config, args ...[]string) error {
+	if !cfg.interactive {
+		fmt.Print(strings.Repeat("\n", clearLines))
 		return nil
 	}
-	pokemonName := args[0][0]
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
+	fmt.Print("\033[H\033[2J")
+	return nil
+}
 
-	url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%s", pokemonName)
-	body, err := makeRequest(url, cfg.cache)
-	if err != nil {
-		fmt.Printf("Could not find Pokémon: %s\n", pokemonName)
+func commandMap(ctx context.Context, cfg *config, args ...[]string) error {
+	// PokeAPI returns a null next link on the final page
+	if cfg.mapStarted && cfg.nextURL == nil {
+		fmt.Println("You're on the last page")
 		return nil
 	}
 
-	var pokeResp struct {
-		Name           string `json:"name"`
-		BaseExperience int    `json:"base_experience"`
-		Height         int    `json:"height"`
-		Weight         int    `json:"weight"`
-		Stats          []struct {
-			BaseStat int `json:"base_stat"`
-			Stat     struct {
-				Name string `json:"name"`
-			} `json:"stat"`
-		} `json:"stats"`
-		Types []struct {
-			Type struct {
-				Name string `json:"name"`
-			} `json:"type"`
-		} `json:"types"`
-	}
-	err = json.Unmarshal(body, &pokeResp)
-	if err != nil {
-		fmt.Println("Error parsing Pokémon data")
-		return nil
-	}
+	url := locationListURL(cfg)
 
-	// Already caught?
-	if _, ok := cfg.pokedex[pokeResp.Name]; ok {
-		fmt.Printf("%s is already in your Pokedex!\n", pokeResp.Name)
-		return nil
+	// If we have a next URL from previous pagination, use it
+	if cfg.nextURL != nil {
+		url = *cfg.nextURL
 	}
 
-	// Determine catch chance: base 50%, minus (base_experience / 2)%, min 1%, max 90%
-	catchChance := 50 - pokeResp.BaseExperience/2
-	if catchChance < 1 {
-		catchChance = 1
+	return showLocationPage(ctx, cfg, url, true)
+}
+
+// defaultPageSize is how many location areas map lists per page, matching PokeAPI's default
+const defaultPageSize = 20
+
+// maxPageSize caps -page-size so a single map page stays readable
+const maxPageSize = 100
+
+// parsePageSize parses a -page-size value, rejecting sizes outside 1 to maxPageSize
+func parsePageSize(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, errors.New("must be a whole number")
 	}
-	if catchChance > 90 {
-		catchChance = 90
+	if n < 1 || n > maxPageSize {
+		return 0, fmt.Errorf("must be between 1 and %d", maxPageSize)
 	}
+	return n, nil
+}
 
-	rand.Seed(time.Now().UnixNano())
-	roll := rand.Intn(100) + 1 // 1-100
-
-	if roll <= catchChance {
-		fmt.Printf("Congratulations! You caught %s!\n", pokeResp.Name)
-		// Prepare stats and types for storage
-		stats := make([]Stat, 0, len(pokeResp.Stats))
-		for _, s := range pokeResp.Stats {
-			stats = append(stats, Stat{
-				Name:  s.Stat.Name,
-				Value: s.BaseStat,
-			})
-		}
-		types := make([]string, 0, len(pokeResp.Types))
-		for _, t := range pokeResp.Types {
-			types = append(types, t.Type.Name)
-		}
-		cfg.pokedex[pokeResp.Name] = Pokemon{
-			Name:           pokeResp.Name,
-			BaseExperience: pokeResp.BaseExperience,
-			Height:         pokeResp.Height,
-			Weight:         pokeResp.Weight,
-			Stats:          stats,
-			Types:          types,
-		}
-	} else {
-		fmt.Printf("%s escaped!\n", pokeResp.Name)
+// locationListURL returns the first location-area list page, sized by cfg.pageSize.
+// Later pages follow PokeAPI's next and previous links, which keep the limit.
+func locationListURL(cfg *config) string {
+	size := cfg.pageSize
+	if size < 1 {
+		size = defaultPageSize
 	}
+	return fmt.Sprintf("%s/location-area?limit=%d", cfg.baseURL, size)
+}
 
-	return nil
+// Pokemon struct for storing caught Pokemon
+type Pokemon struct {
+	ID             int       `json:"id,omitempty"`
+	Name           string    `json:"name"`
+	BaseExperience int       `json:"base_experience"`
+	Height         int       `json:"height"`
+	Weight         int       `json:"weight"`
+	Stats          []Stat    `json:"stats"`
+	Types          []string  `json:"types"`
+	CaughtAt       time.Time `json:"caught_at,omitzero"` // zero for entries saved before this was tracked
 }
 
-func commandInspect(cfg *config, args ...[]string) error {
-	if len(args) == 0 || len(args[0]) == 0 {
-		fmt.Println("You must provide a Pokémon name")
+type Stat struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+func commandInspect(ctx context.Context, cfg *config, args ...[]string) error {
+	pokemonName := cfg.lastCaught
+	if len(args) > 0 && len(args[0]) > 0 {
+		pokemonName = args[0][0]
+	}
+	if pokemonName == "" {
+		fmt.Println("You haven't caught anything yet. Catch a Pokémon first, or name one: inspect <pokemon-name>")
 		return nil
 	}
-	pokemonName := args[0][0]
 	p, ok := cfg.pokedex[pokemonName]
 	if !ok {
-		fmt.Printf("You have not caught %s yet.\n", pokemonName)
+		fmt.Println("You have not caught that Pokémon.")
 		return nil
 	}
+	printPokemon(p)
+	return nil
+}
+
+// printPokemon prints the inspect summary of a caught Pokémon
+func printPokemon(p Pokemon) {
 	fmt.Printf("Name: %s\n", p.Name)
 	fmt.Printf("Height: %d\n", p.Height)
 	fmt.Printf("Weight: %d\n", p.Weight)
+	fmt.Printf("Base experience: %d\n", p.BaseExperience)
 	fmt.Printf("Types: %s\n", strings.Join(p.Types, ", "))
+	if !p.CaughtAt.IsZero() {
+		fmt.Printf("Caught on: %s\n", p.CaughtAt.Format(time.DateOnly))
+	}
+	fmt.Printf("Base stat total: %s\n", formatBST(p))
 	fmt.Println("Stats:")
 	for _, stat := range p.Stats {
 		fmt.Printf("  %s: %d\n", stat.Name, stat.Value)
 	}
-	return nil
 }
 
-// commandPokedex prints the names of all caught Pokémon
-func commandPokedex(cfg *config, args ...[]string) error {
+// commandPokedex prints the names of all caught Pokémon, optionally only those
+// caught --since a date, as a plain list or an aligned table
+func commandPokedex(ctx context.Context, cfg *config, args ...[]string) error {
+	var since time.Time
+	table, asJSON := false, false
+	if len(args) > 0 {
+		rest, jsonFlag := popFlag(args[0], "json")
+		asJSON = jsonFlag
+		rest, sinceArg, hasSince, err := popFlagValue(rest, "since")
+		if err != nil {
+			return userErrorf("%v", err)
+		}
+		if hasSince {
+			since, err = time.ParseInLocation(time.DateOnly, sinceArg, time.Local)
+			if err != nil {
+				return userErrorf("--since must be a date like 2006-01-02")
+			}
+		}
+
+		rest, format, hasFormat, err := popFlagValue(rest, "format")
+		if err != nil {
+			return userErrorf("%v", err)
+		}
+		switch {
+		case hasFormat && format == "table", len(rest) > 0 && rest[0] == "table":
+			table = true
+		case hasFormat && format != "list":
+			return userErrorf("--format must be list or table")
+		}
+	}
+
+	names := caughtSince(cfg.pokedex, since)
+	list := make([]Pokemon, 0, len(names))
+	for _, name := range names {
+		list = append(list, cfg.pokedex[name])
+	}
+	if asJSON {
+		return writeJSON(os.Stdout, list)
+	}
+
 	if len(cfg.pokedex) == 0 {
 		fmt.Println("You haven't caught any Pokémon yet!")
 		return nil
 	}
+
+	if len(names) == 0 {
+		fmt.Printf("You haven't caught any Pokémon since %s\n", since.Format(time.DateOnly))
+		return nil
+	}
 	fmt.Println("Your Pokedex:")
-	for name := range cfg.pokedex {
+	if table {
+		return writePokedexTable(os.Stdout, list)
+	}
+	for _, name := range names {
 		fmt.Printf(" - %s\n", name)
 	}
 	return nil
 }
 
-func commandMapB(cfg *config, args ...[]string) error {
+// caughtSince returns the sorted names of Pokémon caught at or after since.
+// A zero since matches everything, including entries without a CaughtAt.
+func caughtSince(pokedex map[string]Pokemon, since time.Time) []string {
+	names := make([]string, 0, len(pokedex))
+	for name, p := range pokedex {
+		if !since.IsZero() && p.CaughtAt.Before(since) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func commandMapB(ctx context.Context, cfg *config, args ...[]string) error {
 	if cfg.previousURL == nil {
 		fmt.Println("You're on the first page")
 		return nil
 	}
 
-	url := *cfg.previousURL
+	return showLocationPage(ctx, cfg, *cfg.previousURL, false)
+}
 
-	// Use cached request
-	body, err := makeRequest(url, cfg.cache)
-	if err != nil {
-		return err
+// showLocationPage fetches and prints the location-area list page at url,
+// updating the pagination links. If url was a link from the current page and
+// now 404s, PokeAPI's data has changed under a cached page: the current page
+// is re-fetched and its fresh link (next if forward, else previous) is tried once.
+func showLocationPage(ctx context.Context, cfg *config, url string, forward bool) error {
+	names, page, err := fetchLocationPage(ctx, cfg, url)
+	if isNotFound(err) && cfg.currentURL != "" && url != cfg.currentURL {
+		verbosef(cfg, "Location list changed upstream, refreshing...\n")
+		cfg.cache.Delete(cacheKey(cfg, url))
+		cfg.cache.Delete(cacheKey(cfg, cfg.currentURL))
+
+		_, current, refreshErr := fetchLocationPage(ctx, cfg, cfg.currentURL)
+		if refreshErr != nil {
+			return refreshErr
+		}
+		cfg.nextURL = current.Next
+		cfg.previousURL = current.Previous
+		link := current.Previous
+		if forward {
+			link = current.Next
+		}
+		if link == nil {
+			if forward {
+				fmt.Println("You're on the last page")
+			} else {
+				fmt.Println("You're on the first page")
+			}
+			return nil
+		}
+		url = *link
+		names, page, err = fetchLocationPage(ctx, cfg, url)
 	}
-
-	var locationAreasResp LocationAreasResponse
-	err = json.Unmarshal(body, &locationAreasResp)
 	if err != nil {
-		return fmt.Errorf("error unmarshaling JSON: %w", err)
+		return err
 	}
 
 	// Update config with new pagination URLs
-	cfg.nextURL = locationAreasResp.Next
-	cfg.previousURL = locationAreasResp.Previous
+	cfg.currentURL = url
+	cfg.nextURL = page.Next
+	cfg.previousURL = page.Previous
+	cfg.mapStarted = true
 
 	// Display the location areas
 	fmt.Println()
-	for _, result := range locationAreasResp.Results {
-		fmt.Println(result.Name)
+	for _, name := range names {
+		fmt.Println(name)
 	}
 	fmt.Println()
 
 	return nil
 }
+
+// fetchLocationPage fetches a location-area list page, returning its names and links
+func fetchLocationPage(ctx context.Context, cfg *config, url string) ([]string, listPage, error) {
+	// Use cached request
+	body, err := makeRequest(ctx, cfg, url)
+	if err != nil {
+		return nil, listPage{}, err
+	}
+
+	// Stream the results so large pages are never fully unmarshaled
+	var names []string
+	page, err := streamList(bytes.NewReader(body), func(r NamedResource) error {
+		names = append(names, r.Name)
+		return nil
+	})
+	if err != nil {
+		return nil, listPage{}, fmt.Errorf("error unmarshaling JSON: %w", err)
+	}
+	return names, page, nil
+}
@@ -1,10 +1,93 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/deoreal/pokedexcli/internal/pokecache"
 )
 
+// captureOutput runs f and returns everything it wrote to stdout
+func captureOutput(t *testing.T, f func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stdout, f)
+}
+
+// captureStderr returns everything f writes to stderr
+func captureStderr(t *testing.T, f func()) string {
+	t.Helper()
+	return captureFile(t, &os.Stderr, f)
+}
+
+// captureFile swaps *file for a pipe while f runs and returns what was written to it
+func captureFile(t *testing.T, file **os.File, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	orig := *file
+	*file = w
+
+	done := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		done <- buf.String()
+	}()
+
+	f()
+
+	w.Close()
+	*file = orig
+	return <-done
+}
+
+// newTestConfig returns a config with an empty pokedex and a fresh cache
+func newTestConfig(t *testing.T) *config {
+	t.Helper()
+	cache := pokecache.NewCache(5 * time.Second)
+	t.Cleanup(cache.Stop)
+	return &config{
+		baseURL: defaultBaseURL,
+		cache:   cache,
+		pokedex: make(map[string]Pokemon),
+		rng:     rand.New(rand.NewSource(1)),
+	}
+}
+
+// newTestServer serves fixed JSON bodies keyed by request path and 404s everything else
+func newTestServer(t *testing.T, routes map[string]string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
 func TestCleanInput(t *testing.T) {
 	cases := []struct {
 		input    string
@@ -42,6 +125,38 @@ func TestCleanInput(t *testing.T) {
 			input:    "\t\nTabsAndNewlines\t\n",
 			expected: []string{"tabsandnewlines"},
 		},
+		{
+			input:    "catch Flabébé",
+			expected: []string{"catch", "flabébé"},
+		},
+		{
+			input:    "catch FLABÉBÉ",
+			expected: []string{"catch", "flabébé"},
+		},
+		{
+			input:    "catch Flabe\u0301be\u0301",
+			expected: []string{"catch", "flabébé"},
+		},
+		{
+			input:    "catch Nidoran♀",
+			expected: []string{"catch", "nidoran♀"},
+		},
+		{
+			input:    "\uFEFFhelp",
+			expected: []string{"help"},
+		},
+		{
+			input:    "\uFEFF  map  ",
+			expected: []string{"map"},
+		},
+		{
+			input:    "ex\x00plore pasto\x1bria\x7f",
+			expected: []string{"explore", "pastoria"},
+		},
+		{
+			input:    "catch\u0007 pikachu\r",
+			expected: []string{"catch", "pikachu"},
+		},
 	}
 
 	for _, c := range cases {
@@ -65,3 +180,1215 @@ func TestCleanInput(t *testing.T) {
 		}
 	}
 }
+
+func TestREPLExit(t *testing.T) {
+	cfg := newTestConfig(t)
+	input := strings.NewReader("help\nexit\npokedex\n")
+
+	out := captureOutput(t, func() {
+		runREPL(input, cfg)
+	})
+
+	if !cfg.quit {
+		t.Error("expected exit to set cfg.quit")
+	}
+	if !strings.Contains(out, "Closing the Pokedex... Goodbye!") {
+		t.Errorf("expected goodbye message, got %q", out)
+	}
+	if strings.Contains(out, "You haven't caught any Pokémon yet!") {
+		t.Error("commands after exit should not run")
+	}
+}
+
+func TestCatchMinChance(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/mewtwo":   `{"name":"mewtwo","base_experience":340}`,
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":0}`,
+	})
+
+	t.Run("refuses below threshold", func(t *testing.T) {
+		cfg := newTestConfig(t)
+		cfg.baseURL = srv.URL
+		out := captureOutput(t, func() {
+			processInput("catch mewtwo --min-chance 20", cfg)
+		})
+		if !strings.Contains(out, "Catch chance for mewtwo is 1%") {
+			t.Errorf("expected refusal showing chance, got %q", out)
+		}
+		if strings.Contains(out, "Throwing a Pokeball") {
+			t.Error("should not throw when below --min-chance")
+		}
+	})
+
+	t.Run("proceeds at or above threshold", func(t *testing.T) {
+		cfg := newTestConfig(t)
+		cfg.baseURL = srv.URL
+		out := captureOutput(t, func() {
+			processInput("catch caterpie --min-chance 50", cfg)
+		})
+		if !strings.Contains(out, "Throwing a Pokeball at caterpie...") {
+			t.Errorf("expected a throw, got %q", out)
+		}
+	})
+
+	t.Run("rejects out of range threshold", func(t *testing.T) {
+		cfg := newTestConfig(t)
+		cfg.baseURL = srv.URL
+		out := captureOutput(t, func() {
+			processInput("catch caterpie --min-chance 101", cfg)
+		})
+		if !strings.Contains(out, "--min-chance must be a number between 0 and 100") {
+			t.Errorf("expected validation message, got %q", out)
+		}
+	})
+}
+
+func TestExploreDedupesAndSorts(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/location-area/test-area": `{"name":"test-area","pokemon_encounters":[
+			{"pokemon":{"name":"zubat"}},
+			{"pokemon":{"name":"geodude"}},
+			{"pokemon":{"name":"zubat"}},
+			{"pokemon":{"name":"abra"}}
+		]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	out := captureOutput(t, func() {
+		processInput("explore test-area", cfg)
+	})
+	want := " - abra\n - geodude\n - zubat\n"
+	if !strings.Contains(out, want) {
+		t.Errorf("expected sorted, deduped list %q, got %q", want, out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("explore test-area --raw-order", cfg)
+	})
+	want = " - zubat\n - geodude\n - abra\n"
+	if !strings.Contains(out, want) {
+		t.Errorf("expected API order, deduped list %q, got %q", want, out)
+	}
+}
+
+func TestExploreJSON(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/location-area/pastoria-city-area": `{"pokemon_encounters":[
+			{"pokemon":{"name":"tentacool"}},
+			{"pokemon":{"name":"magikarp"}},
+			{"pokemon":{"name":"tentacool"}}
+		]}`,
+		"/location-area/empty-area": `{"pokemon_encounters":[]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	out := captureOutput(t, func() {
+		processInput("explore pastoria-city-area --json", cfg)
+	})
+	var names []string
+	if err := json.Unmarshal([]byte(out), &names); err != nil {
+		t.Fatalf("expected only a JSON array on stdout, got %q: %v", out, err)
+	}
+	if !reflect.DeepEqual(names, []string{"magikarp", "tentacool"}) {
+		t.Errorf("expected sorted, deduped names, got %v", names)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("explore empty-area --json", cfg)
+	})
+	if strings.TrimSpace(out) != "[]" {
+		t.Errorf("expected an empty array, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("explore pastoria-city-area", cfg)
+	})
+	if !strings.Contains(out, "Exploring pastoria-city-area...") || !strings.Contains(out, " - magikarp\n - tentacool\n") {
+		t.Errorf("expected the default output unchanged, got %q", out)
+	}
+}
+
+func TestCaughtSince(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2024, time.March, d, 0, 0, 0, 0, time.Local)
+	}
+	pokedex := map[string]Pokemon{
+		"bulbasaur":  {Name: "bulbasaur", CaughtAt: day(1).Add(23 * time.Hour)},
+		"charmander": {Name: "charmander", CaughtAt: day(2)},
+		"squirtle":   {Name: "squirtle", CaughtAt: day(3).Add(time.Minute)},
+		"legacy":     {Name: "legacy"},
+	}
+
+	cases := []struct {
+		since    time.Time
+		expected []string
+	}{
+		{since: time.Time{}, expected: []string{"bulbasaur", "charmander", "legacy", "squirtle"}},
+		{since: day(1), expected: []string{"bulbasaur", "charmander", "squirtle"}},
+		{since: day(2), expected: []string{"charmander", "squirtle"}},
+		{since: day(3), expected: []string{"squirtle"}},
+		{since: day(4), expected: []string{}},
+	}
+
+	for _, c := range cases {
+		actual := caughtSince(pokedex, c.since)
+		if strings.Join(actual, ",") != strings.Join(c.expected, ",") {
+			t.Errorf("caughtSince(%v) = %v, expected %v", c.since, actual, c.expected)
+		}
+	}
+}
+
+func TestPokedexSinceFlag(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.pokedex["pidgey"] = Pokemon{Name: "pidgey", CaughtAt: time.Date(2024, time.March, 2, 12, 0, 0, 0, time.Local)}
+
+	out := captureOutput(t, func() {
+		processInput("pokedex --since 2024-03-02", cfg)
+	})
+	if !strings.Contains(out, " - pidgey") {
+		t.Errorf("expected pidgey to be listed, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("pokedex --since 2024-03-03", cfg)
+	})
+	if strings.Contains(out, " - pidgey") {
+		t.Errorf("expected pidgey to be filtered out, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("pokedex --since march", cfg)
+	})
+	if !strings.Contains(out, "--since must be a date") {
+		t.Errorf("expected date validation message, got %q", out)
+	}
+}
+
+func TestCatchSummary(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":39}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.summary = true
+
+	// Replay the roll the catch will make from the same seed
+	roll := rand.New(rand.NewSource(1)).Intn(100) + 1
+	chance := catchChance(39)
+	result := "escape"
+	if roll <= chance {
+		result = "success"
+	}
+
+	out := captureOutput(t, func() {
+		processInput("catch caterpie", cfg)
+	})
+	want := fmt.Sprintf("CATCH name=caterpie chance=%d roll=%d result=%s\n", chance, roll, result)
+	if !strings.Contains(out, want) {
+		t.Errorf("expected summary %q, got %q", want, out)
+	}
+
+	cfg.summary = false
+	delete(cfg.pokedex, "caterpie")
+	out = captureOutput(t, func() {
+		processInput("catch caterpie", cfg)
+	})
+	if strings.Contains(out, "CATCH ") {
+		t.Errorf("summary should only print with -summary, got %q", out)
+	}
+}
+
+func TestUnicodeNamesInURLs(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	captureOutput(t, func() {
+		processInput("catch Flabébé", cfg)
+		processInput("catch Nidoran♀", cfg)
+	})
+
+	want := []string{"/pokemon/flabébé", "/pokemon/nidoran♀"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("expected request paths %v, got %v", want, paths)
+	}
+}
+
+func TestCanonicalCacheKeys(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		io.WriteString(w, `{"count":0}`)
+	}))
+	defer srv.Close()
+
+	first := srv.URL + "/location-area?limit=20&offset=0"
+	second := srv.URL + "/location-area?offset=0&limit=20"
+
+	cfg := newTestConfig(t)
+	for _, url := range []string{first, second} {
+		if _, err := makeRequest(context.Background(), cfg, url); err != nil {
+			t.Fatalf("makeRequest(%q): %v", url, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("without canonicalization expected 2 requests, got %d", calls)
+	}
+
+	calls = 0
+	cfg = newTestConfig(t)
+	cfg.canonicalizeCacheKeys = true
+	for _, url := range []string{first, second} {
+		if _, err := makeRequest(context.Background(), cfg, url); err != nil {
+			t.Fatalf("makeRequest(%q): %v", url, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("with canonicalization expected 1 request, got %d", calls)
+	}
+	if cfg.cache.Len() != 1 {
+		t.Errorf("expected a single cache entry, got %d", cfg.cache.Len())
+	}
+}
+
+// newTwoPageServer serves a location-area list split over two pages
+func newTwoPageServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/location-area" {
+			http.NotFound(w, r)
+			return
+		}
+		first := srv.URL + "/location-area"
+		second := srv.URL + "/location-area?offset=2"
+		if r.URL.Query().Get("offset") == "2" {
+			fmt.Fprintf(w, `{"count":3,"next":null,"previous":%q,"results":[{"name":"area-3"}]}`, first)
+			return
+		}
+		fmt.Fprintf(w, `{"count":3,"next":%q,"previous":null,"results":[{"name":"area-1"},{"name":"area-2"}]}`, second)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestMapBoundaries(t *testing.T) {
+	srv := newTwoPageServer(t)
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	out := captureOutput(t, func() {
+		processInput("mapb", cfg)
+	})
+	if !strings.Contains(out, "You're on the first page") {
+		t.Errorf("expected first page message before any map, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("map", cfg)
+		processInput("map", cfg)
+	})
+	if !strings.Contains(out, "area-1") || !strings.Contains(out, "area-3") {
+		t.Fatalf("expected both pages to be listed, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("map", cfg)
+	})
+	if !strings.Contains(out, "You're on the last page") || strings.Contains(out, "area-3") {
+		t.Errorf("expected last page message without re-listing, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("mapb", cfg)
+		processInput("mapb", cfg)
+	})
+	if !strings.Contains(out, "area-1") || !strings.Contains(out, "You're on the first page") {
+		t.Errorf("expected to page back to the first page, got %q", out)
+	}
+}
+
+func TestMapRecoversFromStaleNext(t *testing.T) {
+	// The first page initially links to offset=2, then PokeAPI's data changes
+	// so that page 404s and the first page links to offset=3 instead
+	changed := false
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/location-area" {
+			http.NotFound(w, r)
+			return
+		}
+		offset := r.URL.Query().Get("offset")
+		switch {
+		case offset == "" && !changed:
+			fmt.Fprintf(w, `{"count":3,"next":%q,"previous":null,"results":[{"name":"area-1"}]}`, srv.URL+"/location-area?offset=2")
+		case offset == "":
+			fmt.Fprintf(w, `{"count":3,"next":%q,"previous":null,"results":[{"name":"area-1"}]}`, srv.URL+"/location-area?offset=3")
+		case offset == "3" && changed:
+			fmt.Fprint(w, `{"count":3,"next":null,"previous":null,"results":[{"name":"area-new"}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	captureOutput(t, func() {
+		processInput("map", cfg)
+	})
+	changed = true
+
+	out := captureOutput(t, func() {
+		processInput("map", cfg)
+	})
+	if strings.Contains(out, "Error occurred") || !strings.Contains(out, "area-new") {
+		t.Errorf("expected map to recover from the stale next link, got %q", out)
+	}
+	if cfg.currentURL != srv.URL+"/location-area?offset=3" {
+		t.Errorf("expected the refreshed page to become current, got %q", cfg.currentURL)
+	}
+}
+
+func TestCatchRetryPrompt(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		io.WriteString(w, `{"name":"mewtwo","base_experience":340}`)
+	}))
+	defer srv.Close()
+
+	t.Run("non-interactive throws once", func(t *testing.T) {
+		cfg := newTestConfig(t)
+		cfg.baseURL = srv.URL
+		out := captureOutput(t, func() {
+			runREPL(strings.NewReader("catch mewtwo\ny\n"), cfg)
+		})
+		if strings.Contains(out, "Try again?") {
+			t.Errorf("should not prompt without a terminal, got %q", out)
+		}
+		if n := strings.Count(out, "Throwing a Pokeball"); n != 1 {
+			t.Errorf("expected 1 throw, got %d", n)
+		}
+	})
+
+	t.Run("interactive retries on yes", func(t *testing.T) {
+		calls = 0
+		cfg := newTestConfig(t)
+		cfg.baseURL = srv.URL
+		cfg.interactive = true
+		out := captureOutput(t, func() {
+			runREPL(strings.NewReader("catch mewtwo\ny\nn\npokedex\n"), cfg)
+		})
+		if n := strings.Count(out, "Try again? (y/N)"); n != 2 {
+			t.Errorf("expected 2 prompts, got %d in %q", n, out)
+		}
+		if n := strings.Count(out, "Throwing a Pokeball"); n != 2 {
+			t.Errorf("expected 2 throws, got %d", n)
+		}
+		if calls != 1 {
+			t.Errorf("retries should reuse cached data, got %d requests", calls)
+		}
+		if !strings.Contains(out, "You haven't caught any Pokémon yet!") {
+			t.Errorf("REPL should continue after declining, got %q", out)
+		}
+	})
+}
+
+func TestExploreShowsCacheAge(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/location-area/route-1": `{"pokemon_encounters":[{"pokemon":{"name":"pidgey"}}]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.showCacheAge = true
+
+	now := time.Date(2024, time.March, 2, 12, 0, 0, 0, time.UTC)
+	cfg.cache.(*pokecache.Cache).SetClock(func() time.Time { return now })
+
+	out := captureOutput(t, func() {
+		processInput("explore route-1", cfg)
+	})
+	if strings.Contains(out, "cached") {
+		t.Errorf("live fetch should not be marked cached, got %q", out)
+	}
+
+	now = now.Add(7 * time.Second)
+	out = captureOutput(t, func() {
+		processInput("explore route-1", cfg)
+	})
+	if !strings.Contains(out, "Exploring route-1... (cached 7s ago)") {
+		t.Errorf("expected cache age suffix, got %q", out)
+	}
+
+	cfg.showCacheAge = false
+	out = captureOutput(t, func() {
+		processInput("explore route-1", cfg)
+	})
+	if strings.Contains(out, "cached") {
+		t.Errorf("suffix should only appear with -show-cache-age, got %q", out)
+	}
+}
+
+func TestRegisterCommand(t *testing.T) {
+	var got []string
+	err := RegisterCommand(cliCommand{
+		name:        "echo",
+		description: "Echo the arguments",
+		callback: func(ctx context.Context, cfg *config, args ...[]string) error {
+			got = args[0]
+			return nil
+		},
+		takesArgs: true,
+	})
+	if err != nil {
+		t.Fatalf("RegisterCommand: %v", err)
+	}
+	t.Cleanup(func() { delete(Commands, "echo") })
+
+	cfg := newTestConfig(t)
+	processInput("ECHO Hello  World", cfg)
+	if strings.Join(got, ",") != "hello,world" {
+		t.Errorf("expected [hello world], got %q", got)
+	}
+
+	noop := func(context.Context, *config, ...[]string) error { return nil }
+	for _, cmd := range []cliCommand{
+		{name: "echo", callback: noop},
+		{name: "catch", callback: noop},
+		{name: cancelKey, callback: noop},
+		{name: "m", callback: noop},
+		{name: "", callback: noop},
+		{name: "Upper", callback: noop},
+		{name: "nocallback"},
+	} {
+		if err := RegisterCommand(cmd); err == nil {
+			t.Errorf("expected RegisterCommand(%q) to fail", cmd.name)
+		}
+	}
+}
+
+func TestVerboseCommandTiming(t *testing.T) {
+	err := RegisterCommand(cliCommand{
+		name:        "nap",
+		description: "Sleeps briefly",
+		callback: func(ctx context.Context, cfg *config, args ...[]string) error {
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterCommand: %v", err)
+	}
+	t.Cleanup(func() { delete(Commands, "nap") })
+
+	cfg := newTestConfig(t)
+	if out := captureStderr(t, func() { processInput("nap", cfg) }); out != "" {
+		t.Errorf("expected no timing line without verbose, got %q", out)
+	}
+
+	cfg.verbose = true
+	var stdout string
+	stderr := captureStderr(t, func() {
+		stdout = captureOutput(t, func() { processInput("nap", cfg) })
+	})
+	var ms int
+	if _, err := fmt.Sscanf(stderr, "(nap took %dms)\n", &ms); err != nil || ms < 20 {
+		t.Errorf("expected a timing line of at least 20ms on stderr, got %q", stderr)
+	}
+	if stdout != "" {
+		t.Errorf("expected nothing on stdout, got %q", stdout)
+	}
+}
+
+func TestQuietMode(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":0}`,
+		"/location-area/test-area": `{"name":"test-area","pokemon_encounters":[
+			{"pokemon":{"name":"zubat"}},
+			{"pokemon":{"name":"abra"}}
+		]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.quiet = true
+
+	out := captureOutput(t, func() {
+		processInput("catch caterpie", cfg) // seed 1 rolls 82 against 50%
+	})
+	if out != "escaped\n" {
+		t.Errorf("expected only the catch result, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("explore test-area", cfg)
+	})
+	if out != "abra\nzubat\n" {
+		t.Errorf("expected only the Pokémon names, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("explore", cfg)
+	})
+	if !strings.Contains(out, "You must provide a location area name") {
+		t.Errorf("expected errors to stay visible, got %q", out)
+	}
+}
+
+func TestNegativeCache(t *testing.T) {
+	requests := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests[r.URL.Path]++
+		if r.URL.Path == "/pokemon/flaky" {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.notFound = pokecache.NewCache(time.Minute)
+	t.Cleanup(cfg.notFound.Stop)
+
+	var out string
+	for range 2 {
+		out = captureOutput(t, func() {
+			processInput("catch pikachoo", cfg)
+			processInput("catch flaky", cfg)
+		})
+	}
+	if !strings.Contains(out, "Could not find Pokémon: pikachoo") {
+		t.Errorf("expected the cached 404 to still report not found, got %q", out)
+	}
+	if requests["/pokemon/pikachoo"] != 1 {
+		t.Errorf("expected a known 404 to skip the network, got %d requests", requests["/pokemon/pikachoo"])
+	}
+	if requests["/pokemon/flaky"] != 2 {
+		t.Errorf("expected 5xx responses not to be cached, got %d requests", requests["/pokemon/flaky"])
+	}
+}
+
+func TestGetJSONHealsCorruptCache(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		io.WriteString(w, `{"name":"pikachu","base_experience":112}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	url := srv.URL + "/pokemon/pikachu"
+	cfg.cache.Add(url, []byte(`{"name":"pika`)) // truncated write
+
+	pokeResp, err := fetchPokemon(context.Background(), cfg, "pikachu")
+	if err != nil {
+		t.Fatalf("expected the corrupt entry to be re-fetched, got %v", err)
+	}
+	if pokeResp.Name != "pikachu" || pokeResp.BaseExperience != 112 {
+		t.Errorf("unexpected response %+v", pokeResp)
+	}
+	if requests != 1 {
+		t.Errorf("expected exactly one re-fetch, got %d requests", requests)
+	}
+	if cached, ok := cfg.cache.Get(url); !ok || !strings.Contains(string(cached), "base_experience") {
+		t.Errorf("expected the cache to hold the fresh body, got %q", cached)
+	}
+}
+
+func TestClear(t *testing.T) {
+	cfg := newTestConfig(t)
+	out := captureOutput(t, func() {
+		processInput("clear", cfg)
+	})
+	if out != strings.Repeat("\n", clearLines) {
+		t.Errorf("expected a newline fallback without a terminal, got %q", out)
+	}
+
+	cfg.interactive = true
+	out = captureOutput(t, func() {
+		processInput("clear", cfg)
+	})
+	if out != "\033[H\033[2J" {
+		t.Errorf("expected the ANSI clear sequence, got %q", out)
+	}
+}
+
+func TestInspectDefaultsToLastCaught(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":0,"height":3,"weight":29}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.pokedex["pidgey"] = Pokemon{Name: "pidgey"}
+
+	out := captureOutput(t, func() {
+		processInput("inspect", cfg)
+	})
+	if !strings.Contains(out, "Catch a Pokémon first") {
+		t.Errorf("expected a hint before anything is caught this session, got %q", out)
+	}
+
+	cfg.rng = rand.New(rand.NewSource(6)) // rolls 49 against caterpie's 50%
+	out = captureOutput(t, func() {
+		processInput("catch caterpie", cfg)
+		processInput("inspect", cfg)
+	})
+	if !strings.Contains(out, "You caught caterpie!") || !strings.Contains(out, "Name: caterpie\nHeight: 3\n") {
+		t.Errorf("expected bare inspect to show caterpie, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("inspect pidgey", cfg)
+	})
+	if !strings.Contains(out, "Name: pidgey\n") {
+		t.Errorf("expected an explicit name to still work, got %q", out)
+	}
+}
+
+func TestCatchIsDeterministicWithSeed(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":40}`,
+	})
+	// caterpie's chance is 50 - 40/2 = 30%
+	cases := []struct {
+		seed   int64
+		caught bool
+	}{
+		{1, false},  // rolls 82
+		{4, true},   // rolls 30
+		{20, false}, // rolls 31
+	}
+	for _, c := range cases {
+		cfg := newTestConfig(t)
+		cfg.baseURL = srv.URL
+		cfg.rng = rand.New(rand.NewSource(c.seed))
+		captureOutput(t, func() {
+			processInput("catch caterpie", cfg)
+		})
+		if _, caught := cfg.pokedex["caterpie"]; caught != c.caught {
+			t.Errorf("seed %d: expected caught=%v, got %v", c.seed, c.caught, caught)
+		}
+	}
+
+	if rngSeed(42) != 42 || rngSeed(0) == 0 {
+		t.Error("expected -seed to be used as given and 0 to pick a seed")
+	}
+}
+
+func TestCatchStoresFullPokemon(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pikachu": `{
+			"id": 25,
+			"name": "pikachu",
+			"base_experience": 40,
+			"height": 4,
+			"weight": 60,
+			"abilities": [{"ability": {"name": "static"}, "is_hidden": false, "slot": 1}],
+			"sprites": {"front_default": "https://example.test/25.png"},
+			"stats": [
+				{"base_stat": 35, "effort": 0, "stat": {"name": "hp", "url": "https://pokeapi.co/api/v2/stat/1/"}},
+				{"base_stat": 55, "effort": 0, "stat": {"name": "attack", "url": "https://pokeapi.co/api/v2/stat/2/"}},
+				{"base_stat": 40, "effort": 0, "stat": {"name": "defense", "url": "https://pokeapi.co/api/v2/stat/3/"}}
+			],
+			"types": [{"slot": 1, "type": {"name": "electric", "url": "https://pokeapi.co/api/v2/type/13/"}}]
+		}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.rng = rand.New(rand.NewSource(3)) // rolls 9 against pikachu's 30%
+	captureOutput(t, func() {
+		processInput("catch pikachu", cfg)
+	})
+
+	got, ok := cfg.pokedex["pikachu"]
+	if !ok {
+		t.Fatal("expected pikachu to be caught")
+	}
+	got.CaughtAt = time.Time{}
+	want := Pokemon{
+		ID:             25,
+		Name:           "pikachu",
+		BaseExperience: 40,
+		Height:         4,
+		Weight:         60,
+		Stats:          []Stat{{Name: "hp", Value: 35}, {Name: "attack", Value: 55}, {Name: "defense", Value: 40}},
+		Types:          []string{"electric"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("stored %+v, expected %+v", got, want)
+	}
+}
+
+func TestInspectCaughtAndUncaught(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.pokedex["onix"] = Pokemon{
+		Name:           "onix",
+		BaseExperience: 77,
+		Height:         88,
+		Weight:         2100,
+		Stats:          []Stat{{Name: "hp", Value: 35}, {Name: "defense", Value: 160}},
+		Types:          []string{"rock", "ground"},
+	}
+
+	out := captureOutput(t, func() {
+		processInput("inspect onix", cfg)
+	})
+	want := "Name: onix\nHeight: 88\nWeight: 2100\nBase experience: 77\nTypes: rock, ground\n"
+	if !strings.HasPrefix(out, want) || !strings.Contains(out, "  hp: 35\n  defense: 160\n") {
+		t.Errorf("expected onix's stored details, got %q", out)
+	}
+
+	var err error
+	out = captureOutput(t, func() {
+		err = commandInspect(context.Background(), cfg, []string{"pikachu"})
+	})
+	if err != nil || out != "You have not caught that Pokémon.\n" {
+		t.Errorf("expected a friendly not caught message and no error, got %q, %v", out, err)
+	}
+}
+
+func TestConcurrentRequestsCoalesce(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(50 * time.Millisecond) // keep the first request in flight while the rest arrive
+		io.WriteString(w, `{"name":"pikachu"}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := newTestConfig(t)
+	url := srv.URL + "/pokemon/pikachu"
+
+	const callers = 20
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	bodies := make([]string, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			body, err := makeRequest(context.Background(), cfg, url)
+			if err != nil {
+				t.Errorf("makeRequest: %v", err)
+			}
+			bodies[i] = string(body)
+		}()
+	}
+	close(start)
+	wg.Wait()
+
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected one network call, got %d", n)
+	}
+	for i, body := range bodies {
+		if body != `{"name":"pikachu"}` {
+			t.Errorf("caller %d got %q", i, body)
+		}
+	}
+}
+
+func TestUnreachableAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close() // nothing listens on this address any more
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	_, err := makeRequest(context.Background(), cfg, srv.URL+"/pokemon/pikachu")
+	if !errors.Is(err, ErrOffline) {
+		t.Fatalf("expected ErrOffline, got %v", err)
+	}
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		t.Errorf("expected the dial error to stay wrapped, got %v", err)
+	}
+
+	out := captureOutput(t, func() {
+		processInput("explore canalave-city-area", cfg)
+	})
+	if !strings.Contains(out, "can't reach PokeAPI, check your internet connection or start with -offline") {
+		t.Errorf("expected a friendly error, got %q", out)
+	}
+	if strings.Contains(out, "dial tcp") {
+		t.Errorf("expected the raw dial error to be hidden, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("catch pikachu", cfg)
+	})
+	if !strings.Contains(out, "check your internet connection") || strings.Contains(out, "Could not find") {
+		t.Errorf("expected catch to report the connection failure, not a missing Pokémon, got %q", out)
+	}
+
+	cfg.verbose = true
+	stderr := captureStderr(t, func() {
+		captureOutput(t, func() {
+			processInput("explore canalave-city-area", cfg)
+		})
+	})
+	if !strings.Contains(stderr, "cause:") || !strings.Contains(stderr, "dial tcp") {
+		t.Errorf("expected -verbose to print the underlying dial error, got %q", stderr)
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	srv := newTestServer(t, nil)
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.jsonErrors = true
+
+	out := captureOutput(t, func() {
+		processInput("explore nowhere", cfg)
+		processInput("fly", cfg)
+		processInput("catch missingno", cfg)
+		processInput("release", cfg)
+		processInput("compare pikachu eevee", cfg)
+		processInput("safari stop", cfg)
+		processInput("party swap pikachu", cfg)
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	want := []struct{ command, error string }{
+		{"explore", "failed to fetch location area data: bad status code: 404"},
+		{"fly", "unknown command"},
+		{"catch", "Could not find Pokémon: missingno"},
+		{"release", "Usage: release <pokemon-name>"},
+		{"compare", "You have not caught pikachu.\nYou have not caught eevee."},
+		{"safari", "Usage: safari start <count> | safari end"},
+		{"party", "Usage: party [add|remove <pokemon-name>]"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("expected one JSON line per failure, got %q", out)
+	}
+	for i, line := range lines {
+		var got struct {
+			Error   string `json:"error"`
+			Command string `json:"command"`
+		}
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d is not JSON: %q", i, line)
+		}
+		if got.Command != want[i].command || got.Error != want[i].error {
+			t.Errorf("line %d: expected %+v, got %+v", i, want[i], got)
+		}
+	}
+}
+
+func TestRequestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	cfg := newTestConfig(t)
+	cfg.client = NewClient(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err := makeRequest(context.Background(), cfg, srv.URL+"/pokemon/pikachu")
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Fatalf("expected a timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected makeRequest to give up quickly, took %s", elapsed)
+	}
+}
+
+func TestRequestRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		switch {
+		case r.URL.Path == "/pokemon/missingno":
+			http.NotFound(w, r)
+		case n <= 2:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		default:
+			io.WriteString(w, `{"name":"pikachu"}`)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := newTestConfig(t)
+	cfg.maxRetries = 3
+	cfg.retryBackoff = time.Millisecond
+
+	body, err := makeRequest(context.Background(), cfg, srv.URL+"/pokemon/pikachu")
+	if err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if string(body) != `{"name":"pikachu"}` || calls.Load() != 3 {
+		t.Errorf("expected 3 attempts ending in the body, got %d and %q", calls.Load(), body)
+	}
+
+	calls.Store(10) // past the failures, so only the path decides
+	if _, err := makeRequest(context.Background(), cfg, srv.URL+"/pokemon/missingno"); !isNotFound(err) {
+		t.Fatalf("expected a 404, got %v", err)
+	}
+	if calls.Load() != 11 {
+		t.Errorf("a 404 should not be retried, got %d attempts", calls.Load()-10)
+	}
+
+	cfg.maxRetries = 1
+	calls.Store(0)
+	if _, err := makeRequest(context.Background(), cfg, srv.URL+"/pokemon/eevee"); err == nil {
+		t.Error("expected the request to fail once retries run out")
+	}
+	if calls.Load() != 2 {
+		t.Errorf("expected 1 retry, got %d attempts", calls.Load())
+	}
+}
+
+func TestMapPagination(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") == "2" {
+			fmt.Fprintf(w, `{"next":null,"previous":%q,"results":[{"name":"eterna-forest-area"}]}`, srv.URL+"/location-area")
+			return
+		}
+		fmt.Fprintf(w, `{"next":%q,"previous":null,"results":[{"name":"canalave-city-area"}]}`, srv.URL+"/location-area?offset=2")
+	}))
+	defer srv.Close()
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	out := captureOutput(t, func() {
+		processInput("map", cfg)
+	})
+	if out != "\ncanalave-city-area\n\n" {
+		t.Errorf("expected the first page framed by blank lines, got %q", out)
+	}
+	if cfg.nextURL == nil || *cfg.nextURL != srv.URL+"/location-area?offset=2" || cfg.previousURL != nil {
+		t.Fatalf("expected only a next link after the first page, got next=%v previous=%v", cfg.nextURL, cfg.previousURL)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("map", cfg)
+	})
+	if out != "\neterna-forest-area\n\n" {
+		t.Errorf("expected the second page, got %q", out)
+	}
+	if cfg.nextURL != nil || cfg.previousURL == nil || *cfg.previousURL != srv.URL+"/location-area" {
+		t.Fatalf("expected only a previous link after the second page, got next=%v previous=%v", cfg.nextURL, cfg.previousURL)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("mapb", cfg)
+	})
+	if out != "\ncanalave-city-area\n\n" || cfg.nextURL == nil {
+		t.Errorf("expected mapb to go back to the first page, got %q", out)
+	}
+}
+
+func TestMapPageSize(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.baseURL = "https://pokeapi.test/api/v2"
+	if got := locationListURL(cfg); got != "https://pokeapi.test/api/v2/location-area?limit=20" {
+		t.Errorf("expected the default page size of 20, got %q", got)
+	}
+	cfg.pageSize = 50
+	if got := locationListURL(cfg); got != "https://pokeapi.test/api/v2/location-area?limit=50" {
+		t.Errorf("expected the configured page size, got %q", got)
+	}
+
+	var limit string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit = r.URL.Query().Get("limit")
+		io.WriteString(w, `{"next":null,"previous":null,"results":[{"name":"canalave-city-area"}]}`)
+	}))
+	defer srv.Close()
+	cfg.baseURL = srv.URL
+	captureOutput(t, func() {
+		processInput("map", cfg)
+	})
+	if limit != "50" {
+		t.Errorf("expected map to request limit=50, got %q", limit)
+	}
+}
+
+func TestParsePageSize(t *testing.T) {
+	if n, err := parsePageSize("50"); err != nil || n != 50 {
+		t.Errorf("expected 50 to parse, got %d, %v", n, err)
+	}
+	for _, s := range []string{"0", "-5", "101", "ten"} {
+		if _, err := parsePageSize(s); err == nil {
+			t.Errorf("expected -page-size %s to be rejected", s)
+		}
+	}
+}
+
+func TestOfflineAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	srv := newTestServer(t, map[string]string{
+		"/location-area/canalave-city-area": `{"pokemon_encounters":[{"pokemon":{"name":"tentacool"}}]}`,
+	})
+	session := func(offline bool) string {
+		cache, err := pokecache.NewPersistentCache(time.Hour, dir) // as with -cache-ttl 1h
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(cache.Stop)
+		cfg := newTestConfig(t)
+		cfg.cache = cache
+		cfg.baseURL = srv.URL
+		cfg.offline = offline
+		return captureOutput(t, func() {
+			processInput("explore canalave-city-area", cfg)
+		})
+	}
+
+	session(false)
+	srv.Close() // the network is gone for the next session
+	if out := session(true); !strings.Contains(out, "tentacool") {
+		t.Errorf("expected an area explored last session to be served offline, got %q", out)
+	}
+}
+
+func TestOfflineServesOnlyFromCache(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/location-area/canalave-city-area": `{"pokemon_encounters":[{"pokemon":{"name":"tentacool"}}]}`,
+		"/location-area/eterna-forest-area": `{"pokemon_encounters":[{"pokemon":{"name":"budew"}}]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.endpoints = newEndpointStats()
+
+	cached := srv.URL + "/location-area/canalave-city-area"
+	if _, err := makeRequest(context.Background(), cfg, cached); err != nil {
+		t.Fatal(err)
+	}
+	cfg.offline = true
+
+	if body, err := makeRequest(context.Background(), cfg, cached); err != nil || !strings.Contains(string(body), "tentacool") {
+		t.Errorf("expected the cached body while offline, got %q, %v", body, err)
+	}
+	uncached := srv.URL + "/location-area/eterna-forest-area"
+	_, err := makeRequest(context.Background(), cfg, uncached)
+	if !errors.Is(err, ErrOffline) || err.Error() != "offline: "+uncached+" not cached" {
+		t.Errorf("expected the offline error, got %v", err)
+	}
+	if stats := cfg.endpoints.snapshot(); len(stats) != 1 || stats[0].Requests != 1 {
+		t.Errorf("expected no request to be made while offline, got %+v", stats)
+	}
+}
+
+func TestMakeRequestCancelled(t *testing.T) {
+	arrived := make(chan struct{})
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(arrived)
+		<-release
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	cfg := newTestConfig(t)
+	cfg.maxRetries = 3
+	cfg.retryBackoff = time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-arrived
+		cancel()
+	}()
+
+	_, err := makeRequest(ctx, cfg, srv.URL+"/pokemon/pikachu")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if errors.Is(err, ErrOffline) {
+		t.Errorf("a cancelled request should not look offline, got %v", err)
+	}
+	if _, found := cfg.cache.Get(srv.URL + "/pokemon/pikachu"); found {
+		t.Error("a cancelled request should not be cached")
+	}
+}
+
+func TestHelpForCommand(t *testing.T) {
+	cfg := newTestConfig(t)
+	run := func(input string) string {
+		return captureOutput(t, func() {
+			processInput(input, cfg)
+		})
+	}
+
+	out := run("help")
+	if !strings.Contains(out, "Welcome to the Pokedex!") || !strings.Contains(out, "exit: Exit the Pokedex") {
+		t.Errorf("expected the full list, got %q", out)
+	}
+
+	out = run("help catch")
+	for _, want := range []string{
+		"catch: Try to catch a Pokémon by name\n",
+		"  catch <pokemon-name> [--min-chance N] [--ball=greatball|ultraball|masterball]\n",
+		"  catch --range <start> <end>\n",
+		"Example: catch pikachu --min-chance 20\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+	if strings.Contains(out, "explore") || strings.Contains(out, "Welcome") {
+		t.Errorf("expected only catch's help, got %q", out)
+	}
+
+	if out := run("help map"); strings.Contains(out, "mapb") || !strings.Contains(out, "Example: map\n") {
+		t.Errorf("expected map's help alone with its name as example, got %q", out)
+	}
+	if out := run("help e"); !strings.Contains(out, "Displays the Pokémon in a location area") {
+		t.Errorf("expected help to follow aliases, got %q", out)
+	}
+	for _, name := range []string{"fly", "selftest"} {
+		if out := run("help " + name); out != "No help available for "+name+".\n" {
+			t.Errorf("help %s: expected no help, got %q", name, out)
+		}
+	}
+}
+
+func TestLoadSavedDataSurvivesCorruptFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"catch-queue.json", "achievements.json"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("{not json"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "pokedex.json"), []byte(`[{"name":"pikachu"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := newTestConfig(t)
+	stderr := captureStderr(t, func() {
+		loadSavedData(cfg, dir)
+	})
+	for _, want := range []string{"empty catch queue", "no achievements"} {
+		if !strings.Contains(stderr, want) {
+			t.Errorf("expected a %q warning, got %q", want, stderr)
+		}
+	}
+	if _, ok := cfg.pokedex["pikachu"]; !ok || cfg.pokedexPath == "" {
+		t.Errorf("expected the valid pokedex to load, got %v", cfg.pokedex)
+	}
+	if cfg.queuePath != "" || cfg.unlockedPath != "" {
+		t.Errorf("expected corrupt files to be kept in memory only, got %q and %q", cfg.queuePath, cfg.unlockedPath)
+	}
+
+	captureOutput(t, func() {
+		checkAchievements(context.Background(), cfg)
+	})
+	if data, _ := os.ReadFile(filepath.Join(dir, "achievements.json")); string(data) != "{not json" {
+		t.Errorf("expected the corrupt file to be left alone, got %q", data)
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+// sessionCounters are running totals for the -serve /metrics endpoint
+// (cache hits and misses come from the cache's own Stats)
+type sessionCounters struct {
+	catchAttempts atomic.Int64
+	catches       atomic.Int64
+}
+
+// writeMetrics writes the session counters in the Prometheus text exposition format
+func writeMetrics(w io.Writer, cfg *config) {
+	metric := func(name, kind, help string) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+
+	metric("pokedex_requests_total", "counter", "Network requests made to PokeAPI, by endpoint.")
+	for _, stat := range cfg.endpoints.snapshot() {
+		fmt.Fprintf(w, "pokedex_requests_total{endpoint=%q} %d\n", stat.Pattern, stat.Requests)
+	}
+
+	hits, misses := cfg.cache.Stats()
+	metric("pokedex_cache_hits_total", "counter", "Cache lookups that found their key.")
+	fmt.Fprintf(w, "pokedex_cache_hits_total %d\n", hits)
+	metric("pokedex_cache_misses_total", "counter", "Cache lookups that missed.")
+	fmt.Fprintf(w, "pokedex_cache_misses_total %d\n", misses)
+	metric("pokedex_cache_entries", "gauge", "Entries in the cache.")
+	fmt.Fprintf(w, "pokedex_cache_entries %d\n", cfg.cache.Len())
+	metric("pokedex_cache_bytes", "gauge", "Total size of cached values in bytes.")
+	fmt.Fprintf(w, "pokedex_cache_bytes %d\n", cfg.cache.SizeBytes())
+
+	metric("pokedex_catch_attempts_total", "counter", "Pokeballs thrown.")
+	fmt.Fprintf(w, "pokedex_catch_attempts_total %d\n", cfg.counters.catchAttempts.Load())
+	metric("pokedex_catches_total", "counter", "Successful catches.")
+	fmt.Fprintf(w, "pokedex_catches_total %d\n", cfg.counters.catches.Load())
+	metric("pokedex_caught", "gauge", "Pokémon in the pokedex.")
+	fmt.Fprintf(w, "pokedex_caught %d\n", len(cfg.pokedex))
+}
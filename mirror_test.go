@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMirrorFallback(t *testing.T) {
+	primaryCalls := 0
+	primary := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		primaryCalls++
+		if r.URL.Path == "/pokemon/missingno" {
+			http.NotFound(w, r)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer primary.Close()
+	mirrorCalls := 0
+	mirror := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mirrorCalls++
+		io.WriteString(w, `{"name":"pikachu"}`)
+	}))
+	defer mirror.Close()
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = primary.URL
+	cfg.mirrorURL = mirror.URL
+	var trace bytes.Buffer
+	cfg.trace = &trace
+	cfg.verbose = true
+
+	url := primary.URL + "/pokemon/pikachu"
+	body, err := makeRequest(context.Background(), cfg, url)
+	if err != nil {
+		t.Fatalf("makeRequest: %v", err)
+	}
+	if string(body) != `{"name":"pikachu"}` {
+		t.Errorf("expected the mirror's body, got %q", body)
+	}
+	if primaryCalls != 1 || mirrorCalls != 1 {
+		t.Errorf("expected one call to each host, got primary=%d mirror=%d", primaryCalls, mirrorCalls)
+	}
+	if _, ok := cfg.cache.Get(url); !ok {
+		t.Error("expected the body cached under the primary URL")
+	}
+	if !strings.Contains(trace.String(), "mirror "+mirror.URL) || !strings.Contains(trace.String(), mirror.URL+"/pokemon/pikachu") {
+		t.Errorf("expected the trace to show the mirror serving the request, got %q", trace.String())
+	}
+
+	// A 404 is a real answer, not an outage, so the mirror isn't asked
+	if _, err := makeRequest(context.Background(), cfg, primary.URL+"/pokemon/missingno"); !isNotFound(err) {
+		t.Errorf("expected a 404, got %v", err)
+	}
+	if mirrorCalls != 1 {
+		t.Errorf("a 404 should not fall back to the mirror, got %d mirror calls", mirrorCalls)
+	}
+}
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// maxFetchPages caps how many linked pages fetchAllPages follows, in case a
+// response's next link loops back on itself
+const maxFetchPages = 100
+
+// pagedResponse is a PokeAPI list response with results of type T
+type pagedResponse[T any] struct {
+	Next    *string `json:"next"`
+	Results []T     `json:"results"`
+}
+
+// fetchAllPages follows next links from startURL, accumulating every page's
+// results. Requests go through the cache. On failure the results gathered so
+// far are returned along with the error.
+func fetchAllPages[T any](ctx context.Context, cfg *config, startURL string) ([]T, error) {
+	var all []T
+	url := startURL
+	for range maxFetchPages {
+		page, err := getJSON[pagedResponse[T]](ctx, cfg, url)
+		if err != nil {
+			return all, fmt.Errorf("failed to fetch list page: %w", err)
+		}
+		all = append(all, page.Results...)
+
+		if page.Next == nil {
+			return all, nil
+		}
+		url = *page.Next
+	}
+	return all, fmt.Errorf("stopped after %d pages", maxFetchPages)
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFetchAllPages(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/list":
+			fmt.Fprintf(w, `{"next":%q,"results":[{"name":"a"},{"name":"b"}]}`, srv.URL+"/list/2")
+		case "/list/2":
+			fmt.Fprintf(w, `{"next":%q,"results":[{"name":"c"}]}`, srv.URL+"/list/3")
+		case "/list/3":
+			fmt.Fprint(w, `{"next":null,"results":[{"name":"d"},{"name":"e"}]}`)
+		case "/broken":
+			fmt.Fprintf(w, `{"next":%q,"results":[{"name":"x"}]}`, srv.URL+"/missing")
+		case "/loop":
+			fmt.Fprintf(w, `{"next":%q,"results":[{"name":"y"}]}`, srv.URL+"/loop")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	cfg := newTestConfig(t)
+
+	got, err := fetchAllPages[NamedResource](context.Background(), cfg, srv.URL+"/list")
+	if err != nil {
+		t.Fatalf("fetchAllPages: %v", err)
+	}
+	var names []string
+	for _, r := range got {
+		names = append(names, r.Name)
+	}
+	if strings.Join(names, ",") != "a,b,c,d,e" {
+		t.Errorf("expected results from all three pages, got %v", names)
+	}
+
+	got, err = fetchAllPages[NamedResource](context.Background(), cfg, srv.URL+"/broken")
+	if err == nil || len(got) != 1 || got[0].Name != "x" {
+		t.Errorf("expected the results before the broken link along with an error, got %v, %v", got, err)
+	}
+
+	got, err = fetchAllPages[NamedResource](context.Background(), cfg, srv.URL+"/loop")
+	if err == nil || len(got) != maxFetchPages {
+		t.Errorf("expected a looping list to stop at %d pages with an error, got %d, %v", maxFetchPages, len(got), err)
+	}
+}
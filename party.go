@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// synergyBonus is the catch chance bonus for a Pokémon that covers a party weakness
+const synergyBonus = 5
+
+// commandParty lists, adds or removes caught Pokémon in the battle party
+func commandParty(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) == 0 {
+		if len(cfg.party) == 0 {
+			fmt.Println("Your party is empty. Add Pokémon with: party add <name>")
+			return nil
+		}
+		fmt.Println("Your party:")
+		for _, name := range cfg.party {
+			fmt.Printf(" - %s\n", name)
+		}
+		return nil
+	}
+
+	if len(args[0]) < 2 {
+		return userErrorf("Usage: party [add|remove <pokemon-name>]")
+	}
+	sub, name := args[0][0], args[0][1]
+	switch sub {
+	case "add":
+		if _, ok := cfg.pokedex[name]; !ok {
+			return userErrorf("You have not caught %s yet.", name)
+		}
+		if slices.Contains(cfg.party, name) {
+			return userErrorf("%s is already in your party.", name)
+		}
+		if len(cfg.party) >= maxTeamSize {
+			return userErrorf("Your party is full (%d Pokémon).", maxTeamSize)
+		}
+		cfg.party = append(cfg.party, name)
+		fmt.Printf("%s joined your party.\n", name)
+	case "remove":
+		i := slices.Index(cfg.party, name)
+		if i < 0 {
+			return userErrorf("%s is not in your party.", name)
+		}
+		cfg.party = slices.Delete(cfg.party, i, i+1)
+		fmt.Printf("%s left your party.\n", name)
+	default:
+		return userErrorf("Usage: party [add|remove <pokemon-name>]")
+	}
+	return nil
+}
+
+// partySynergy reports whether any of types is super effective against a type
+// the current party is weak to, i.e. the newcomer would cover a party weakness
+func partySynergy(ctx context.Context, cfg *config, types []string) (bool, error) {
+	weaknesses := make(map[string]bool)
+	for _, name := range cfg.party {
+		for _, t := range cfg.pokedex[name].Types {
+			attackers, err := fetchWeaknesses(ctx, cfg, t)
+			if err != nil {
+				return false, err
+			}
+			for _, a := range attackers {
+				weaknesses[a] = true
+			}
+		}
+	}
+	if len(weaknesses) == 0 {
+		return false, nil
+	}
+
+	for _, t := range types {
+		targets, err := fetchSuperEffective(ctx, cfg, t)
+		if err != nil {
+			return false, err
+		}
+		for _, target := range targets {
+			if weaknesses[target] {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
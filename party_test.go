@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPartyCommand(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.pokedex["charmander"] = Pokemon{Name: "charmander", Types: []string{"fire"}}
+
+	out := captureOutput(t, func() {
+		processInput("party add squirtle", cfg)
+		processInput("party add charmander", cfg)
+		processInput("party add charmander", cfg)
+		processInput("party", cfg)
+		processInput("party remove charmander", cfg)
+	})
+	for _, want := range []string{
+		"You have not caught squirtle yet.",
+		"charmander joined your party.",
+		"charmander is already in your party.",
+		"Your party:\n - charmander\n",
+		"charmander left your party.",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in %q", want, out)
+		}
+	}
+	if len(cfg.party) != 0 {
+		t.Errorf("expected empty party, got %v", cfg.party)
+	}
+}
+
+func TestCatchSynergyBonus(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/geodude": `{"name":"geodude","base_experience":60,"types":[{"type":{"name":"rock"}},{"type":{"name":"ground"}}]}`,
+		"/pokemon/pidgey":  `{"name":"pidgey","base_experience":50,"types":[{"type":{"name":"flying"}}]}`,
+		"/type/fire":       `{"name":"fire","damage_relations":{"double_damage_from":[{"name":"water"},{"name":"ground"},{"name":"rock"}]}}`,
+		"/type/rock":       `{"name":"rock","damage_relations":{"double_damage_to":[{"name":"fire"},{"name":"ice"},{"name":"flying"},{"name":"bug"}]}}`,
+		"/type/ground":     `{"name":"ground","damage_relations":{"double_damage_to":[{"name":"fire"},{"name":"electric"},{"name":"rock"}]}}`,
+		"/type/flying":     `{"name":"flying","damage_relations":{"double_damage_to":[{"name":"grass"},{"name":"fighting"},{"name":"bug"}]}}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.synergy = true
+	cfg.pokedex["charmander"] = Pokemon{Name: "charmander", Types: []string{"fire"}}
+	cfg.party = []string{"charmander"}
+
+	// --min-chance 100 refuses every throw, printing the final chance
+	out := captureOutput(t, func() {
+		processInput("catch geodude --min-chance 100", cfg)
+	})
+	if !strings.Contains(out, "+5% party synergy bonus") || !strings.Contains(out, "geodude is 25%") {
+		t.Errorf("expected ground to cover charmander's rock weakness for a bonus, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("catch pidgey --min-chance 100", cfg)
+	})
+	if strings.Contains(out, "synergy") || !strings.Contains(out, "pidgey is 25%") {
+		t.Errorf("expected no bonus for pidgey, got %q", out)
+	}
+
+	cfg.synergy = false
+	out = captureOutput(t, func() {
+		processInput("catch geodude --min-chance 100", cfg)
+	})
+	if strings.Contains(out, "synergy") || !strings.Contains(out, "geodude is 20%") {
+		t.Errorf("expected no bonus without -synergy, got %q", out)
+	}
+}
+
+func TestClampChance(t *testing.T) {
+	for input, expected := range map[int]int{-10: 1, 0: 1, 1: 1, 50: 50, 90: 90, 95: 90} {
+		if actual := clampChance(input); actual != expected {
+			t.Errorf("clampChance(%d) = %d, expected %d", input, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultPasteWindow is how soon after one line the next must arrive for the
+// two to count as a single paste rather than separate keystrokes
+const defaultPasteWindow = 25 * time.Millisecond
+
+// collectPaste gathers first plus every line that follows within window of
+// the previous one. Nobody types that fast, so more than one line means the
+// user pasted a block. Blank lines are dropped.
+func collectPaste(first string, lines <-chan string, window time.Duration) []string {
+	var batch []string
+	add := func(line string) {
+		// A trailing tab is kept for expandCompletion
+		if strings.TrimSpace(line) != "" {
+			batch = append(batch, strings.Trim(line, " \r\n"))
+		}
+	}
+	add(first)
+
+	timer := time.NewTimer(window)
+	defer timer.Stop()
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return batch
+			}
+			add(line)
+			timer.Reset(window)
+		case <-timer.C:
+			return batch
+		}
+	}
+}
+
+// confirmPaste previews a pasted block and asks before running it; unlike
+// confirm, an empty answer means yes
+func confirmPaste(cfg *config, batch []string) bool {
+	fmt.Println("Pasted commands:")
+	for _, line := range batch {
+		fmt.Printf("  %s\n", line)
+	}
+	fmt.Printf("Run %d commands? (Y/n) ", len(batch))
+	line, ok := <-cfg.lines
+	if !ok {
+		return false
+	}
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "" || answer == "y" || answer == "yes"
+}
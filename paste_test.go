@@ -0,0 +1,64 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollectPaste(t *testing.T) {
+	lines := make(chan string)
+	go func() {
+		lines <- "map"
+		lines <- ""
+		lines <- "explore pastoria-city-area"
+		time.Sleep(200 * time.Millisecond)
+		lines <- "pokedex"
+	}()
+
+	batch := collectPaste("help", lines, 50*time.Millisecond)
+	want := []string{"help", "map", "explore pastoria-city-area"}
+	if !reflect.DeepEqual(batch, want) {
+		t.Errorf("expected %v, got %v", want, batch)
+	}
+	if line := <-lines; line != "pokedex" {
+		t.Errorf("a line typed after the window should be left for the REPL, got %q", line)
+	}
+}
+
+func TestPasteNonInteractiveRunsImmediately(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.pasteWindow = time.Second
+
+	out := captureOutput(t, func() {
+		runREPL(strings.NewReader("pokedex\nhelp\nexit\n"), cfg)
+	})
+
+	if strings.Contains(out, "Run 3 commands?") {
+		t.Errorf("should not prompt without a terminal, got %q", out)
+	}
+	if !strings.Contains(out, "You haven't caught any Pokémon yet!") || !strings.Contains(out, "Welcome to the Pokedex!") {
+		t.Errorf("expected every command to run, got %q", out)
+	}
+}
+
+func TestConfirmPaste(t *testing.T) {
+	for answer, want := range map[string]bool{"": true, "y": true, "n": false} {
+		lines := make(chan string, 1)
+		lines <- answer
+		cfg := newTestConfig(t)
+		cfg.lines = lines
+
+		var got bool
+		out := captureOutput(t, func() {
+			got = confirmPaste(cfg, []string{"catch pikachu", "inspect"})
+		})
+		if got != want {
+			t.Errorf("answer %q: expected %v, got %v", answer, want, got)
+		}
+		if !strings.Contains(out, "  catch pikachu\n  inspect\nRun 2 commands? (Y/n)") {
+			t.Errorf("expected a preview and prompt, got %q", out)
+		}
+	}
+}
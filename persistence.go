@@ -0,0 +1,37 @@
+package main
+
+// persistenceStep is the catch chance bonus per consecutive failed throw at
+// the same Pokémon (-persistence), up to persistenceMaxBonus
+const (
+	persistenceStep     = 3
+	persistenceMaxBonus = 15
+)
+
+// persistenceBonus returns the bonus earned by earlier failed throws at name.
+// Attempting a different Pokémon forfeits any bonus built up on another.
+func persistenceBonus(cfg *config, name string) int {
+	if !cfg.persistence {
+		return 0
+	}
+	for other := range cfg.failedAttempts {
+		if other != name {
+			delete(cfg.failedAttempts, other)
+		}
+	}
+	return min(cfg.failedAttempts[name]*persistenceStep, persistenceMaxBonus)
+}
+
+// recordAttempt counts a failed throw at name, or clears the count on a catch
+func recordAttempt(cfg *config, name string, caught bool) {
+	if !cfg.persistence {
+		return
+	}
+	if caught {
+		delete(cfg.failedAttempts, name)
+		return
+	}
+	if cfg.failedAttempts == nil {
+		cfg.failedAttempts = make(map[string]int)
+	}
+	cfg.failedAttempts[name]++
+}
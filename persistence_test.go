@@ -0,0 +1,41 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestPersistenceBonus(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/mewtwo":   `{"name":"mewtwo","base_experience":340}`,
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":0}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.summary = true
+	cfg.persistence = true
+
+	out := captureOutput(t, func() {
+		for range 7 {
+			processInput("catch mewtwo", cfg)
+		}
+	})
+
+	// mewtwo's base chance is 1%; each failure adds 3% up to a 15% bonus
+	var chances []string
+	for _, m := range regexp.MustCompile(`CATCH name=mewtwo chance=(\d+) roll=\d+ result=escape`).FindAllStringSubmatch(out, -1) {
+		chances = append(chances, m[1])
+	}
+	if got, want := strings.Join(chances, ","), "1,4,7,10,13,16,16"; got != want {
+		t.Errorf("expected chances %s across consecutive failures, got %s (output %q)", want, got, out)
+	}
+
+	// Trying a different Pokémon forfeits the built-up bonus
+	captureOutput(t, func() {
+		processInput("catch caterpie", cfg)
+	})
+	if bonus := persistenceBonus(cfg, "mewtwo"); bonus != 0 {
+		t.Errorf("expected the bonus to reset after switching Pokémon, got %d", bonus)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// maxTypesWidth is how wide the Types column may grow before it is truncated
+const maxTypesWidth = 16
+
+// sortedPokedex returns the caught Pokémon ordered by name
+func sortedPokedex(pokedex map[string]Pokemon) []Pokemon {
+	names := make([]string, 0, len(pokedex))
+	for name := range pokedex {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	list := make([]Pokemon, 0, len(names))
+	for _, name := range names {
+		list = append(list, pokedex[name])
+	}
+	return list
+}
+
+// marshalPokedex encodes the pokedex as an indented JSON array sorted by name,
+// so saved files and exports diff cleanly
+func marshalPokedex(pokedex map[string]Pokemon) ([]byte, error) {
+	return json.MarshalIndent(sortedPokedex(pokedex), "", "  ")
+}
+
+// savePokedex writes the caught Pokémon to path, creating its directory if needed
+func savePokedex(cfg *config, path string) error {
+	data, err := marshalPokedex(cfg.pokedex)
+	if err != nil {
+		return fmt.Errorf("error encoding pokedex: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("error creating pokedex directory: %w", err)
+	}
+	if err := atomicWrite(path, data); err != nil {
+		return fmt.Errorf("error writing pokedex file: %w", err)
+	}
+	return nil
+}
+
+// persistPokedex saves the pokedex to cfg.pokedexPath, if set, after every
+// change, so Ctrl-C or a crash loses nothing. A failed save is reported on
+// stderr rather than failing the command that changed the pokedex.
+func persistPokedex(cfg *config) {
+	if cfg.pokedexPath == "" {
+		return
+	}
+	if err := savePokedex(cfg, cfg.pokedexPath); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+// loadPokedex reads a pokedex written by savePokedex. A missing file yields an empty pokedex.
+func loadPokedex(path string) (map[string]Pokemon, error) {
+	pokedex := make(map[string]Pokemon)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return pokedex, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading pokedex file: %w", err)
+	}
+
+	var list []Pokemon
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("error decoding pokedex file: %w", err)
+	}
+	for _, p := range list {
+		pokedex[p.Name] = p
+	}
+	return pokedex, nil
+}
+
+// writePokedexTable writes list as aligned Name, BaseExp and Types columns
+func writePokedexTable(w io.Writer, list []Pokemon) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Name\tBaseExp\tTypes")
+	for _, p := range list {
+		fmt.Fprintf(tw, "%s\t%d\t%s\n", p.Name, p.BaseExperience, truncate(strings.Join(p.Types, ", "), maxTypesWidth))
+	}
+	return tw.Flush()
+}
+
+// truncate shortens s to at most width runes, marking the cut with "..."
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-3]) + "..."
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPokemonJSONRoundTrip(t *testing.T) {
+	original := Pokemon{
+		Name:           "pikachu",
+		BaseExperience: 112,
+		Height:         4,
+		Weight:         60,
+		Stats: []Stat{
+			{Name: "hp", Value: 35},
+			{Name: "speed", Value: 90},
+		},
+		Types:    []string{"electric"},
+		CaughtAt: time.Date(2024, time.March, 2, 15, 4, 5, 0, time.UTC),
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var decoded Pokemon
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(original, decoded) {
+		t.Errorf("round trip mismatch:\n got %+v\nwant %+v", decoded, original)
+	}
+}
+
+func TestMarshalPokedexIsDeterministic(t *testing.T) {
+	pokedex := map[string]Pokemon{}
+	for _, name := range []string{"zubat", "abra", "mew", "eevee", "pidgey", "onix"} {
+		pokedex[name] = Pokemon{Name: name}
+	}
+
+	first, err := marshalPokedex(pokedex)
+	if err != nil {
+		t.Fatalf("marshalPokedex: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		again, err := marshalPokedex(pokedex)
+		if err != nil {
+			t.Fatalf("marshalPokedex: %v", err)
+		}
+		if string(again) != string(first) {
+			t.Fatalf("serialization is not stable:\n%s\nvs\n%s", first, again)
+		}
+	}
+
+	var list []Pokemon
+	if err := json.Unmarshal(first, &list); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	for i := 1; i < len(list); i++ {
+		if list[i-1].Name > list[i].Name {
+			t.Errorf("entries not sorted by name: %q before %q", list[i-1].Name, list[i].Name)
+		}
+	}
+}
+
+func TestSaveLoadPokedex(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pokedex.json")
+	cfg := newTestConfig(t)
+	cfg.pokedex["eevee"] = Pokemon{Name: "eevee", BaseExperience: 65, Types: []string{"normal"}}
+
+	if err := savePokedex(cfg, path); err != nil {
+		t.Fatalf("savePokedex: %v", err)
+	}
+	loaded, err := loadPokedex(path)
+	if err != nil {
+		t.Fatalf("loadPokedex: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cfg.pokedex) {
+		t.Errorf("loaded %+v, expected %+v", loaded, cfg.pokedex)
+	}
+
+	missing, err := loadPokedex(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || len(missing) != 0 {
+		t.Errorf("missing file should load as empty pokedex, got %v, %v", missing, err)
+	}
+}
+
+func TestExitSavesPokedex(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.pokedexPath = filepath.Join(t.TempDir(), "new-dir", "pokedex.json")
+	cfg.pokedex["pikachu"] = Pokemon{Name: "pikachu", BaseExperience: 112, Types: []string{"electric"}}
+	cfg.pokedex["onix"] = Pokemon{Name: "onix", BaseExperience: 77, Types: []string{"rock", "ground"}}
+
+	captureOutput(t, func() {
+		processInput("exit", cfg)
+	})
+	loaded, err := loadPokedex(cfg.pokedexPath)
+	if err != nil {
+		t.Fatalf("loadPokedex: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cfg.pokedex) {
+		t.Errorf("loaded %+v, expected %+v", loaded, cfg.pokedex)
+	}
+}
+
+func TestExitQuitsWhenSaveFails(t *testing.T) {
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := newTestConfig(t)
+	cfg.pokedexPath = filepath.Join(blocker, "pokedex.json") // its directory is a file
+	cfg.pokedex["pikachu"] = Pokemon{Name: "pikachu"}
+
+	out := captureOutput(t, func() {
+		processInput("exit", cfg)
+	})
+	if !cfg.quit || !strings.Contains(out, "Goodbye!") {
+		t.Errorf("expected exit to quit despite the failed save, got %q (quit=%v)", out, cfg.quit)
+	}
+}
+
+func TestChangesSavePokedexImmediately(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.pokedexPath = filepath.Join(t.TempDir(), "pokedex.json")
+	cfg.pokedex["pikachu"] = Pokemon{Name: "pikachu"}
+	cfg.pokedex["onix"] = Pokemon{Name: "onix"}
+
+	captureOutput(t, func() {
+		processInput("release onix", cfg)
+	})
+	loaded, err := loadPokedex(cfg.pokedexPath)
+	if err != nil {
+		t.Fatalf("loadPokedex: %v", err)
+	}
+	if _, ok := loaded["onix"]; ok || len(loaded) != 1 {
+		t.Errorf("expected the release saved without exit, got %v", loaded)
+	}
+}
+
+func TestWritePokedexTable(t *testing.T) {
+	list := []Pokemon{
+		{Name: "bulbasaur", BaseExperience: 64, Types: []string{"grass", "poison"}},
+		{Name: "mew", BaseExperience: 300, Types: []string{"psychic"}},
+		{Name: "oddity", BaseExperience: 5, Types: []string{"normal", "flying", "fairy"}},
+	}
+
+	var buf bytes.Buffer
+	if err := writePokedexTable(&buf, list); err != nil {
+		t.Fatalf("writePokedexTable: %v", err)
+	}
+
+	golden := "" +
+		"Name       BaseExp  Types\n" +
+		"bulbasaur  64       grass, poison\n" +
+		"mew        300      psychic\n" +
+		"oddity     5        normal, flyin...\n"
+	if buf.String() != golden {
+		t.Errorf("table mismatch:\n got:\n%s\nwant:\n%s", buf.String(), golden)
+	}
+}
+
+func TestPokedexTableCommand(t *testing.T) {
+	cfg := newTestConfig(t)
+	out := captureOutput(t, func() {
+		processInput("pokedex table", cfg)
+	})
+	if !strings.Contains(out, "You haven't caught any Pokémon yet!") {
+		t.Errorf("expected empty message, got %q", out)
+	}
+
+	cfg.pokedex["mew"] = Pokemon{Name: "mew", BaseExperience: 300, Types: []string{"psychic"}}
+	for _, input := range []string{"pokedex table", "pokedex --format table"} {
+		out = captureOutput(t, func() {
+			processInput(input, cfg)
+		})
+		if !strings.Contains(out, "Name  BaseExp  Types\nmew   300      psychic\n") {
+			t.Errorf("%q: expected table output, got %q", input, out)
+		}
+	}
+}
+
+func TestPokedexJSONIsStable(t *testing.T) {
+	cfg := newTestConfig(t)
+	for i, name := range []string{"zubat", "abra", "mew", "eevee", "pidgey", "onix"} {
+		cfg.pokedex[name] = Pokemon{
+			Name:           name,
+			BaseExperience: i * 10,
+			Types:          []string{"normal"},
+			CaughtAt:       time.Date(2024, time.March, i+1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+
+	first := captureOutput(t, func() {
+		processInput("pokedex --json", cfg)
+	})
+	second := captureOutput(t, func() {
+		processInput("pokedex --json", cfg)
+	})
+	if first != second {
+		t.Fatalf("pokedex --json output differs between runs:\n%s\nvs\n%s", first, second)
+	}
+
+	var list []Pokemon
+	if err := json.Unmarshal([]byte(first), &list); err != nil {
+		t.Fatalf("invalid JSON %q: %v", first, err)
+	}
+	if len(list) != 6 || list[0].Name != "abra" || list[5].Name != "zubat" {
+		t.Errorf("expected entries sorted by name, got %+v", list)
+	}
+
+	empty := captureOutput(t, func() {
+		processInput("pokedex --json", newTestConfig(t))
+	})
+	if empty != "[]\n" {
+		t.Errorf("expected an empty JSON array, got %q", empty)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// queueCatch records an intent to catch name while offline, ignoring names
+// already queued, and saves the queue
+func queueCatch(cfg *config, name string) error {
+	if slices.Contains(cfg.catchQueue, name) {
+		fmt.Printf("%s is already queued. Run sync once you're back online.\n", name)
+		return nil
+	}
+	cfg.catchQueue = append(cfg.catchQueue, name)
+	fmt.Printf("Offline: queued %s. Run sync once you're back online.\n", name)
+	return saveQueue(cfg)
+}
+
+// saveQueue writes the catch queue to cfg.queuePath, if set
+func saveQueue(cfg *config) error {
+	if cfg.queuePath == "" {
+		return nil
+	}
+	data, err := json.Marshal(cfg.catchQueue)
+	if err != nil {
+		return fmt.Errorf("error encoding catch queue: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.queuePath), 0o755); err != nil {
+		return fmt.Errorf("error creating catch queue directory: %w", err)
+	}
+	if err := atomicWrite(cfg.queuePath, data); err != nil {
+		return fmt.Errorf("error writing catch queue: %w", err)
+	}
+	return nil
+}
+
+// loadQueue reads a catch queue written by saveQueue. A missing file yields an empty queue.
+func loadQueue(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading catch queue: %w", err)
+	}
+	var queue []string
+	if err := json.Unmarshal(data, &queue); err != nil {
+		return nil, fmt.Errorf("error decoding catch queue: %w", err)
+	}
+	return queue, nil
+}
+
+// commandSync replays catches queued while offline. Names PokeAPI can't
+// serve right now stay queued for the next sync.
+func commandSync(ctx context.Context, cfg *config, args ...[]string) error {
+	if cfg.offline {
+		return userErrorf("You're still offline. Run set offline off, then sync.")
+	}
+	if len(cfg.catchQueue) == 0 {
+		fmt.Println("No queued catches")
+		return nil
+	}
+
+	var caught, escaped, kept int
+	var remaining []string
+	for _, name := range cfg.catchQueue {
+		pokeResp, err := fetchPokemon(ctx, cfg, name)
+		if isNotFound(err) {
+			fmt.Printf("Could not find Pokémon: %s\n", name)
+			continue
+		}
+		if err != nil {
+			fmt.Printf("Could not reach PokeAPI for %s, keeping it queued\n", name)
+			remaining = append(remaining, name)
+			kept++
+			continue
+		}
+
+		result, err := attemptCatch(ctx, cfg, pokeResp, 0, 0, defaultBall, false)
+		if err != nil {
+			fmt.Printf("Error catching %s: %v\n", name, err)
+			remaining = append(remaining, name)
+			kept++
+			continue
+		}
+		switch result {
+		case catchCaught:
+			caught++
+		case catchEscaped:
+			escaped++
+		}
+	}
+
+	cfg.catchQueue = remaining
+	fmt.Printf("Synced: %d caught, %d escaped, %d still queued\n", caught, escaped, kept)
+	return saveQueue(cfg)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestOfflineQueueSync(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pidgey":   `{"name":"pidgey","base_experience":0}`,
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":0}`,
+	})
+	queuePath := filepath.Join(t.TempDir(), "catch-queue.json")
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = "http://127.0.0.1:0" // unreachable while offline
+	cfg.offline = true
+	cfg.queuePath = queuePath
+
+	out := captureOutput(t, func() {
+		processInput("catch pidgey", cfg)
+		processInput("catch caterpie", cfg)
+		processInput("catch pidgey", cfg)
+		processInput("sync", cfg)
+	})
+	if !slices.Equal(cfg.catchQueue, []string{"pidgey", "caterpie"}) {
+		t.Errorf("expected a deduplicated queue, got %v", cfg.catchQueue)
+	}
+	if !strings.Contains(out, "pidgey is already queued") || !strings.Contains(out, "You're still offline") {
+		t.Errorf("unexpected offline output %q", out)
+	}
+	if len(cfg.pokedex) != 0 {
+		t.Error("expected no catches while offline")
+	}
+
+	// A restart reloads the queue, and sync replays it against the API
+	queue, err := loadQueue(queuePath)
+	if err != nil {
+		t.Fatalf("loadQueue: %v", err)
+	}
+	cfg = newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.catchQueue = queue
+	cfg.queuePath = queuePath
+	cfg.summary = true
+
+	out = captureOutput(t, func() {
+		processInput("sync", cfg)
+	})
+	// Seed 1 rolls 82 then 88 against a 50% chance, so both escape
+	if !strings.Contains(out, "CATCH name=pidgey") || !strings.Contains(out, "CATCH name=caterpie") {
+		t.Errorf("expected both queued catches to be thrown, got %q", out)
+	}
+	if !strings.Contains(out, "Synced: 0 caught, 2 escaped, 0 still queued") {
+		t.Errorf("expected a sync summary, got %q", out)
+	}
+	if queue, _ := loadQueue(queuePath); len(queue) != 0 {
+		t.Errorf("expected the saved queue to be emptied, got %v", queue)
+	}
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+// randomPokemonID picks a national dex number from 1 to maxNationalDexID
+func randomPokemonID(rng *rand.Rand) int {
+	return rng.Intn(maxNationalDexID) + 1
+}
+
+// commandRandom tries to catch a Pokémon picked at random from the national
+// dex, passing any catch options such as --ball through to catch
+func commandRandom(ctx context.Context, cfg *config, args ...[]string) error {
+	id := randomPokemonID(cfg.rng)
+	fmt.Printf("Randomly chose #%d\n", id)
+
+	catchArgs := []string{strconv.Itoa(id)}
+	if len(args) > 0 {
+		catchArgs = append(catchArgs, args[0]...)
+	}
+	return commandCatch(ctx, cfg, catchArgs)
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestRandomPokemonIDBounds(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 10000 {
+		if id := randomPokemonID(rng); id < 1 || id > maxNationalDexID {
+			t.Fatalf("expected an ID from 1 to %d, got %d", maxNationalDexID, id)
+		}
+	}
+}
+
+func TestRandomCatch(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/407": `{"id":407,"name":"roserade","base_experience":232}`,
+	})
+	cfg := newTestConfig(t) // seed 1 picks #407
+	cfg.baseURL = srv.URL
+
+	out := captureOutput(t, func() {
+		processInput("random --ball=masterball", cfg)
+	})
+	if !strings.Contains(out, "Randomly chose #407") || !strings.Contains(out, "You caught roserade!") {
+		t.Errorf("expected #407 picked and caught, got %q", out)
+	}
+	if _, ok := cfg.pokedex["roserade"]; !ok {
+		t.Error("expected roserade in the pokedex")
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// allAreasLimit is the page size used to fetch the location-area list in a
+// single request; PokeAPI has about 1,100 areas, and next links are still
+// followed should it ever outgrow this
+const allAreasLimit = 10000
+
+// allAreaNames returns every location-area name, fetching the full list on
+// first use and keeping it for the rest of the session
+func allAreaNames(ctx context.Context, cfg *config) ([]string, error) {
+	if cfg.areaNames != nil {
+		return cfg.areaNames, nil
+	}
+	areas, err := fetchAllPages[NamedResource](ctx, cfg, fmt.Sprintf("%s/location-area?limit=%d", cfg.baseURL, allAreasLimit))
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(areas))
+	for _, area := range areas {
+		names = append(names, area.Name)
+	}
+	cfg.areaNames = names
+	return names, nil
+}
+
+// commandRandomArea explores a location area picked at random
+func commandRandomArea(ctx context.Context, cfg *config, args ...[]string) error {
+	names, err := allAreaNames(ctx, cfg)
+	if err != nil {
+		return userErrorf("Could not fetch the location area list: %v", err)
+	}
+	if len(names) == 0 {
+		fmt.Println("No location areas found")
+		return nil
+	}
+
+	name := names[cfg.rng.Intn(len(names))]
+	fmt.Printf("Randomly chose %s\n", name)
+	return commandExplore(ctx, cfg, []string{name})
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRandomArea(t *testing.T) {
+	listRequests := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/location-area":
+			listRequests++
+			if r.URL.Query().Get("limit") == fmt.Sprint(allAreasLimit) {
+				fmt.Fprint(w, `{"next":null,"results":[{"name":"canalave-city-area"},{"name":"eterna-city-area"},{"name":"mt-moon-1f"}]}`)
+				return
+			}
+			if r.URL.Query().Get("offset") == "2" {
+				fmt.Fprint(w, `{"next":null,"results":[{"name":"mt-moon-1f"}]}`)
+				return
+			}
+			fmt.Fprintf(w, `{"next":%q,"results":[{"name":"canalave-city-area"},{"name":"eterna-city-area"}]}`, srv.URL+"/location-area?offset=2")
+		case "/location-area/mt-moon-1f":
+			fmt.Fprint(w, `{"name":"mt-moon-1f","pokemon_encounters":[{"pokemon":{"name":"zubat"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	// Seed 1's first Intn(3) is 2, the last area
+	out := captureOutput(t, func() {
+		processInput("random-area", cfg)
+	})
+	if !strings.Contains(out, "Randomly chose mt-moon-1f\n") || !strings.Contains(out, " - zubat\n") {
+		t.Errorf("expected mt-moon-1f to be explored, got %q", out)
+	}
+
+	cfg.cache = nil // the list must come from the session copy, not the cache
+	names, err := allAreaNames(context.Background(), cfg)
+	if err != nil || len(names) != 3 || listRequests != 1 {
+		t.Errorf("expected the 3-area list to be fetched in a single request, got %v, %v after %d requests", names, err, listRequests)
+	}
+
+	cfg = newTestConfig(t)
+	cfg.baseURL = srv.URL + "/broken"
+	out = captureOutput(t, func() {
+		processInput("random-area", cfg)
+	})
+	if !strings.Contains(out, "Could not fetch the location area list") {
+		t.Errorf("expected a graceful failure, got %q", out)
+	}
+}
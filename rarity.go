@@ -0,0 +1,68 @@
+package main
+
+import "fmt"
+
+// Rarity buckets for explore --by-rarity, by a Pokémon's highest encounter
+// chance across game versions: Common at 30% or more, Uncommon from 10% to
+// 29%, Rare below 10%. Pokémon without chance data are Unknown.
+const (
+	commonMinChance   = 30
+	uncommonMinChance = 10
+)
+
+// rarityOrder is the order explore --by-rarity prints the groups in
+var rarityOrder = []string{"Common", "Uncommon", "Rare", "Unknown"}
+
+// rarityOf returns the rarity group for a max encounter chance
+func rarityOf(maxChance int) string {
+	switch {
+	case maxChance <= 0:
+		return "Unknown"
+	case maxChance >= commonMinChance:
+		return "Common"
+	case maxChance >= uncommonMinChance:
+		return "Uncommon"
+	default:
+		return "Rare"
+	}
+}
+
+// encounterMaxChances returns each Pokémon's highest encounter chance in the
+// area, from version max_chance or, failing that, the individual encounter details
+func encounterMaxChances(area LocationAreaResponse) map[string]int {
+	chances := make(map[string]int, len(area.PokemonEncounters))
+	for _, encounter := range area.PokemonEncounters {
+		best := chances[encounter.Pokemon.Name]
+		for _, version := range encounter.VersionDetails {
+			best = max(best, version.MaxChance)
+			for _, detail := range version.EncounterDetails {
+				best = max(best, detail.Chance)
+			}
+		}
+		chances[encounter.Pokemon.Name] = best
+	}
+	return chances
+}
+
+// printByRarity prints names grouped under rarity headers, keeping their order within each group
+func printByRarity(area LocationAreaResponse, names []string) {
+	chances := encounterMaxChances(area)
+	groups := make(map[string][]string, len(rarityOrder))
+	for _, name := range names {
+		rarity := rarityOf(chances[name])
+		groups[rarity] = append(groups[rarity], name)
+	}
+	for _, rarity := range rarityOrder {
+		if len(groups[rarity]) == 0 {
+			continue
+		}
+		fmt.Printf("%s:\n", rarity)
+		for _, name := range groups[rarity] {
+			if chances[name] > 0 {
+				fmt.Printf(" - %s (%d%%)\n", name, chances[name])
+			} else {
+				fmt.Printf(" - %s\n", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExploreByRarity(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/location-area/meadow": `{"name":"meadow","pokemon_encounters":[
+			{"pokemon":{"name":"pidgey"},"version_details":[{"max_chance":20},{"max_chance":45}]},
+			{"pokemon":{"name":"rattata"},"version_details":[{"max_chance":30}]},
+			{"pokemon":{"name":"oddish"},"version_details":[{"max_chance":10}]},
+			{"pokemon":{"name":"abra"},"version_details":[{"max_chance":0,"encounter_details":[{"chance":5}]}]},
+			{"pokemon":{"name":"chansey"},"version_details":[{"max_chance":9}]},
+			{"pokemon":{"name":"missingno"}}
+		]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	out := captureOutput(t, func() {
+		processInput("explore meadow --by-rarity", cfg)
+	})
+	want := "Found Pokémon:\n" +
+		"Common:\n - pidgey (45%)\n - rattata (30%)\n" +
+		"Uncommon:\n - oddish (10%)\n" +
+		"Rare:\n - abra (5%)\n - chansey (9%)\n" +
+		"Unknown:\n - missingno\n"
+	if !strings.Contains(out, want) {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
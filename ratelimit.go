@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// rateLimitNoticeThreshold is how long a request must be held back before the
+// user is told, so ordinary spacing between requests stays silent
+const rateLimitNoticeThreshold = 250 * time.Millisecond
+
+// rateLimiter spaces out network requests. Reserve claims the next request
+// slot and returns how long the caller must wait before using it.
+type rateLimiter interface {
+	Reserve() time.Duration
+}
+
+// intervalLimiter allows one request per interval
+type intervalLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time // earliest time the next request may start
+	now      func() time.Time
+}
+
+// newIntervalLimiter returns a limiter allowing up to rps requests per second
+func newIntervalLimiter(rps float64) *intervalLimiter {
+	return &intervalLimiter{
+		interval: time.Duration(float64(time.Second) / rps),
+		now:      time.Now,
+	}
+}
+
+func (l *intervalLimiter) Reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	wait := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	return wait
+}
+
+// waitForRateLimit blocks until cfg.limiter allows a request or ctx is done.
+// A wait long enough to notice is reported on stderr at a terminal or with
+// -verbose, unless -quiet or -json asked for bare output.
+func waitForRateLimit(ctx context.Context, cfg *config) error {
+	if cfg.limiter == nil {
+		return nil
+	}
+	wait := cfg.limiter.Reserve()
+	if wait <= 0 {
+		return nil
+	}
+	if wait >= rateLimitNoticeThreshold && (cfg.verbose || cfg.interactive) && !cfg.quiet && !cfg.jsonErrors {
+		fmt.Fprintf(os.Stderr, "rate-limited, waiting %.1fs...\n", wait.Seconds())
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeLimiter returns a fixed wait for every request
+type fakeLimiter struct {
+	wait     time.Duration
+	reserved int
+}
+
+func (f *fakeLimiter) Reserve() time.Duration {
+	f.reserved++
+	return f.wait
+}
+
+func TestRateLimitNotice(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pikachu": `{"name":"pikachu"}`,
+		"/pokemon/eevee":   `{"name":"eevee"}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.interactive = true
+	limiter := &fakeLimiter{wait: 300 * time.Millisecond}
+	cfg.limiter = limiter
+
+	out := captureStderr(t, func() {
+		makeRequest(context.Background(), cfg, srv.URL+"/pokemon/pikachu")
+		makeRequest(context.Background(), cfg, srv.URL+"/pokemon/pikachu") // cached, no wait
+	})
+	if limiter.reserved != 1 {
+		t.Errorf("expected only network requests to be limited, got %d reservations", limiter.reserved)
+	}
+	if !strings.Contains(out, "rate-limited, waiting 0.3s...") {
+		t.Errorf("expected a wait notice, got %q", out)
+	}
+
+	cfg.interactive = false
+	cfg.verbose = true
+	out = captureStderr(t, func() {
+		makeRequest(context.Background(), cfg, srv.URL+"/pokemon/eevee")
+	})
+	if !strings.Contains(out, "rate-limited") {
+		t.Errorf("expected a notice with -verbose, got %q", out)
+	}
+
+	for _, quiet := range []bool{true, false} {
+		cfg.quiet, cfg.jsonErrors = quiet, !quiet
+		cfg.cache.Delete(srv.URL + "/pokemon/eevee")
+		out = captureStderr(t, func() {
+			makeRequest(context.Background(), cfg, srv.URL+"/pokemon/eevee")
+		})
+		if out != "" {
+			t.Errorf("expected no notice with -quiet or -json, got %q", out)
+		}
+	}
+	if limiter.reserved != 4 {
+		t.Errorf("expected every uncached request to wait, got %d reservations", limiter.reserved)
+	}
+}
+
+func TestIntervalLimiter(t *testing.T) {
+	now := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newIntervalLimiter(2)
+	limiter.now = func() time.Time { return now }
+
+	var waits []time.Duration
+	for range 3 {
+		waits = append(waits, limiter.Reserve())
+	}
+	if waits[0] != 0 || waits[1] != 500*time.Millisecond || waits[2] != time.Second {
+		t.Errorf("expected rapid requests to queue 500ms apart, got %v", waits)
+	}
+
+	now = now.Add(5 * time.Second)
+	if wait := limiter.Reserve(); wait != 0 {
+		t.Errorf("expected no wait after idling, got %v", wait)
+	}
+}
+
+func TestRateLimitWaitCancelled(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.limiter = &fakeLimiter{wait: time.Hour}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start := time.Now()
+	_, err := makeRequest(ctx, cfg, "http://pokeapi.test/pokemon/pikachu")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected the cancellation to come through, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("expected the rate-limit wait to stop on cancellation")
+	}
+
+	cfg.baseURL = "http://pokeapi.test"
+	if err := commandCatch(ctx, cfg, []string{"pikachu"}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected catch to report the cancellation, got %v", err)
+	}
+}
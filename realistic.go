@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	neturl "net/url"
+)
+
+// captureProbability implements the Gen III+ capture formula:
+//
+//	a = floor((3*maxHP - 2*curHP) * captureRate * ball / (3*maxHP))
+//	b = floor(1048560 / sqrt(sqrt(16711680 / a)))
+//	p = (b / 65536)^4, or 1 when a >= 255
+//
+// hpFraction is curHP/maxHP in (0, 1] and ball is the ball multiplier
+// (1 for a Poké Ball). Status bonuses are not modelled. a is clamped to [1, 255].
+func captureProbability(captureRate int, ball, hpFraction float64) float64 {
+	hpFraction = math.Min(math.Max(hpFraction, 0.01), 1)
+	a := math.Floor((3 - 2*hpFraction) * float64(captureRate) * ball / 3)
+	a = math.Min(math.Max(a, 1), 255)
+	if a >= 255 {
+		return 1
+	}
+	b := math.Floor(1048560 / math.Sqrt(math.Sqrt(16711680/a)))
+	return math.Pow(b/65536, 4)
+}
+
+// realisticCatchChance converts the capture formula into a 1-100 percent chance
+// for a Poké Ball thrown at a full-HP Pokémon
+func realisticCatchChance(captureRate int) int {
+	chance := int(math.Round(captureProbability(captureRate, 1, 1) * 100))
+	if chance < 1 {
+		chance = 1
+	}
+	if chance > 100 {
+		chance = 100
+	}
+	return chance
+}
+
+// SpeciesResponse is the subset of /pokemon-species/{name} the CLI uses
+type SpeciesResponse struct {
+	CaptureRate    int `json:"capture_rate"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+}
+
+// fetchSpecies fetches and decodes the (cached) species endpoint
+func fetchSpecies(ctx context.Context, cfg *config, name string) (SpeciesResponse, error) {
+	url := fmt.Sprintf("%s/pokemon-species/%s", cfg.baseURL, neturl.PathEscape(name))
+	species, err := getJSON[SpeciesResponse](ctx, cfg, url)
+	if err != nil {
+		return species, fmt.Errorf("failed to fetch species data: %w", err)
+	}
+	return species, nil
+}
+
+// fetchCaptureRate returns a species' capture_rate from the (cached) species endpoint
+func fetchCaptureRate(ctx context.Context, cfg *config, name string) (int, error) {
+	species, err := fetchSpecies(ctx, cfg, name)
+	if err != nil {
+		return 0, err
+	}
+	return species.CaptureRate, nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestCaptureProbability(t *testing.T) {
+	// Reference values for a Poké Ball at full HP with no status condition
+	cases := []struct {
+		name        string
+		captureRate int
+		expected    float64
+	}{
+		{name: "pikachu", captureRate: 190, expected: 0.2470},
+		{name: "bulbasaur", captureRate: 45, expected: 0.0588},
+		{name: "mewtwo", captureRate: 3, expected: 0.0039},
+		{name: "caterpie", captureRate: 255, expected: 0.3333},
+	}
+
+	for _, c := range cases {
+		actual := captureProbability(c.captureRate, 1, 1)
+		if math.Abs(actual-c.expected) > 0.0005 {
+			t.Errorf("%s: captureProbability(%d) = %.4f, expected %.4f", c.name, c.captureRate, actual, c.expected)
+		}
+	}
+
+	if p := captureProbability(3, 255, 1); p != 1 {
+		t.Errorf("a >= 255 should guarantee a catch, got %.4f", p)
+	}
+	if low, high := captureProbability(45, 1, 1), captureProbability(45, 1, 0.1); high <= low {
+		t.Errorf("lower HP should raise the probability: full=%.4f low=%.4f", low, high)
+	}
+}
+
+func TestRealisticCatchChance(t *testing.T) {
+	if got := realisticCatchChance(190); got != 25 {
+		t.Errorf("realisticCatchChance(190) = %d, expected 25", got)
+	}
+	if got := realisticCatchChance(3); got != 1 {
+		t.Errorf("realisticCatchChance(3) = %d, expected clamp to 1", got)
+	}
+}
+
+func TestCatchRealisticUsesSpecies(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pikachu":         `{"name":"pikachu","base_experience":112}`,
+		"/pokemon-species/pikachu": `{"name":"pikachu","capture_rate":190}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.realistic = true
+
+	out := captureOutput(t, func() {
+		processInput("catch pikachu --min-chance 50", cfg)
+	})
+	if !strings.Contains(out, "Catch chance for pikachu is 25%") {
+		t.Errorf("expected the realistic chance to be used, got %q", out)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+)
+
+// RegionalPokedexResponse is the /pokedex/{region} response
+type RegionalPokedexResponse struct {
+	Name           string `json:"name"`
+	PokemonEntries []struct {
+		EntryNumber    int `json:"entry_number"`
+		PokemonSpecies struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"pokemon_species"`
+	} `json:"pokemon_entries"`
+}
+
+// commandRegiondex shows how much of a regional pokedex has been caught
+func commandRegiondex(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) == 0 {
+		return userErrorf("You must provide a region, e.g. kanto")
+	}
+
+	rest, showMissing := popFlag(args[0], "missing")
+	if len(rest) == 0 {
+		return userErrorf("You must provide a region, e.g. kanto")
+	}
+
+	region := rest[0]
+	url := fmt.Sprintf("%s/pokedex/%s", cfg.baseURL, neturl.PathEscape(region))
+
+	// Use cached request
+	dex, err := getJSON[RegionalPokedexResponse](ctx, cfg, url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch regional pokedex: %w", err)
+	}
+
+	var missing []string
+	for _, entry := range dex.PokemonEntries {
+		if _, ok := cfg.pokedex[entry.PokemonSpecies.Name]; !ok {
+			missing = append(missing, entry.PokemonSpecies.Name)
+		}
+	}
+
+	total := len(dex.PokemonEntries)
+	fmt.Printf("%s: %d/%d caught\n", region, total-len(missing), total)
+	if showMissing {
+		for _, name := range missing {
+			fmt.Printf(" - %s\n", name)
+		}
+	}
+	return nil
+}
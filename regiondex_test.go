@@ -0,0 +1,31 @@
+package main
+
+import "testing"
+
+func TestRegiondex(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokedex/kanto": `{"name":"kanto","pokemon_entries":[
+			{"entry_number":1,"pokemon_species":{"name":"bulbasaur"}},
+			{"entry_number":4,"pokemon_species":{"name":"charmander"}},
+			{"entry_number":7,"pokemon_species":{"name":"squirtle"}}
+		]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.pokedex["charmander"] = Pokemon{Name: "charmander"}
+	cfg.pokedex["chikorita"] = Pokemon{Name: "chikorita"}
+
+	out := captureOutput(t, func() {
+		processInput("regiondex kanto", cfg)
+	})
+	if want := "kanto: 1/3 caught\n"; out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("regiondex kanto --missing", cfg)
+	})
+	if want := "kanto: 1/3 caught\n - bulbasaur\n - squirtle\n"; out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// commandRelease removes a caught Pokémon from the pokedex, keeping it in a
+// one-slot undo buffer for restore
+func commandRelease(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) == 0 {
+		return userErrorf("Usage: release <pokemon-name>")
+	}
+	name := args[0][0]
+	p, ok := cfg.pokedex[name]
+	if !ok {
+		fmt.Printf("You haven't caught %s.\n", name)
+		return nil
+	}
+
+	delete(cfg.pokedex, name)
+	if i := slices.Index(cfg.party, name); i >= 0 {
+		cfg.party = slices.Delete(cfg.party, i, i+1)
+	}
+	if cfg.lastCaught == name {
+		cfg.lastCaught = ""
+	}
+	cfg.released = &p
+	persistPokedex(cfg)
+	fmt.Printf("You released %s.\n", name)
+	flavorf(cfg, "Changed your mind? Use restore.\n")
+	return nil
+}
+
+// commandRestore undoes the most recent release
+func commandRestore(ctx context.Context, cfg *config, args ...[]string) error {
+	p := cfg.released
+	if p == nil {
+		fmt.Println("There's nothing to restore.")
+		return nil
+	}
+	cfg.released = nil
+	if _, ok := cfg.pokedex[p.Name]; ok {
+		return userErrorf("%s is already in your Pokedex!", p.Name)
+	}
+	cfg.pokedex[p.Name] = *p
+	persistPokedex(cfg)
+	fmt.Printf("Welcomed %s back!\n", p.Name)
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestReleaseRestore(t *testing.T) {
+	cfg := newTestConfig(t)
+	captureOutput(t, func() {
+		throwBall(context.Background(), cfg, Pokemon{Name: "pikachu", BaseExperience: 112}, defaultBall, 100)
+		throwBall(context.Background(), cfg, Pokemon{Name: "eevee", BaseExperience: 65}, defaultBall, 100)
+	})
+	cfg.party = []string{"pikachu"}
+
+	out := captureOutput(t, func() {
+		processInput("release pikachu", cfg)
+	})
+	if _, ok := cfg.pokedex["pikachu"]; ok {
+		t.Fatal("expected pikachu to be released")
+	}
+	if len(cfg.party) != 0 {
+		t.Errorf("expected pikachu to leave the party, got %v", cfg.party)
+	}
+	if !strings.Contains(out, "You released pikachu.") {
+		t.Errorf("expected release message, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("restore", cfg)
+	})
+	if !strings.Contains(out, "Welcomed pikachu back!") {
+		t.Errorf("expected welcome back message, got %q", out)
+	}
+	if p, ok := cfg.pokedex["pikachu"]; !ok || p.BaseExperience != 112 {
+		t.Errorf("expected pikachu restored intact, got %+v", p)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("restore", cfg)
+	})
+	if !strings.Contains(out, "There's nothing to restore.") {
+		t.Errorf("restore should clear the buffer, got %q", out)
+	}
+
+	// Only the most recent release can be undone
+	captureOutput(t, func() {
+		processInput("release pikachu", cfg)
+		processInput("release eevee", cfg)
+		processInput("restore", cfg)
+	})
+	if _, ok := cfg.pokedex["pikachu"]; ok {
+		t.Error("an overwritten release should stay released")
+	}
+	if _, ok := cfg.pokedex["eevee"]; !ok {
+		t.Error("expected eevee to be restored")
+	}
+}
+
+func TestReleaseNotCaught(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.pokedex["eevee"] = Pokemon{Name: "eevee"}
+
+	var err error
+	out := captureOutput(t, func() {
+		err = commandRelease(context.Background(), cfg, []string{"pikachu"})
+	})
+	if err != nil || !strings.Contains(out, "You haven't caught pikachu.") {
+		t.Errorf("expected a not caught message and no error, got %q, %v", out, err)
+	}
+	if _, ok := cfg.pokedex["eevee"]; !ok || len(cfg.pokedex) != 1 {
+		t.Errorf("expected the pokedex untouched, got %v", cfg.pokedex)
+	}
+	if cfg.released != nil {
+		t.Error("a failed release should not fill the undo buffer")
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxSafariBalls caps safari start, like the games' 30 Safari Balls
+const maxSafariBalls = 30
+
+// safariSession is a Safari Zone minigame: a fixed number of Safari Balls,
+// each throw using one, which catch better than a regular ball (x1.5 chance)
+type safariSession struct {
+	balls  int      // Safari Balls left
+	thrown int      // Safari Balls used so far
+	caught []string // Pokémon caught this session, in order
+}
+
+// safariChance returns chance boosted for a Safari Ball, leaving a
+// guaranteed catch guaranteed
+func safariChance(chance int) int {
+	if chance >= 100 {
+		return chance
+	}
+	return clampChance(chance * 3 / 2)
+}
+
+// commandSafari starts or ends a Safari Zone session
+func commandSafari(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) == 0 {
+		return userErrorf("Usage: safari start <count> | safari end")
+	}
+	switch args[0][0] {
+	case "start":
+		if cfg.safari != nil {
+			return userErrorf("You're already in the Safari Zone with %d Safari Balls left.", cfg.safari.balls)
+		}
+		if len(args[0]) < 2 {
+			return userErrorf("Usage: safari start <count>")
+		}
+		count, err := strconv.Atoi(args[0][1])
+		if err != nil || count < 1 || count > maxSafariBalls {
+			return userErrorf("count must be a number between 1 and %d", maxSafariBalls)
+		}
+		cfg.safari = &safariSession{balls: count}
+		fmt.Printf("Welcome to the Safari Zone! You have %d Safari Balls.\n", count)
+	case "end":
+		if cfg.safari == nil {
+			return userErrorf("You're not in the Safari Zone.")
+		}
+		endSafari(cfg)
+	default:
+		return userErrorf("Usage: safari start <count> | safari end")
+	}
+	return nil
+}
+
+// useSafariBall takes a Safari Ball for the next throw, reporting false when none are left
+func useSafariBall(cfg *config) bool {
+	if cfg.safari.balls == 0 {
+		fmt.Println("You're out of Safari Balls!")
+		return false
+	}
+	cfg.safari.balls--
+	cfg.safari.thrown++
+	return true
+}
+
+// recordSafariThrow notes the outcome of a Safari Ball throw and ends the
+// session once the last ball is used, reporting whether it ended
+func recordSafariThrow(cfg *config, name string, caught bool) bool {
+	if caught {
+		cfg.safari.caught = append(cfg.safari.caught, name)
+	}
+	if cfg.safari.balls > 0 {
+		flavorf(cfg, "%d Safari Balls left\n", cfg.safari.balls)
+		return false
+	}
+	fmt.Println("That was your last Safari Ball!")
+	endSafari(cfg)
+	return true
+}
+
+// endSafari prints the session summary and leaves the Safari Zone
+func endSafari(cfg *config) {
+	s := cfg.safari
+	cfg.safari = nil
+	fmt.Printf("Safari Game over: %d caught with %d Safari Balls", len(s.caught), s.thrown)
+	if s.balls > 0 {
+		fmt.Printf(" (%d unused)", s.balls)
+	}
+	fmt.Println()
+	if len(s.caught) > 0 {
+		fmt.Printf("Caught: %s\n", strings.Join(s.caught, ", "))
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSafariBallDepletion(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/mewtwo": `{"name":"mewtwo","base_experience":340}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.interactive = true
+
+	out := captureOutput(t, func() {
+		runREPL(strings.NewReader("safari start 2\ncatch mewtwo\ny\ny\n"), cfg)
+	})
+	if n := strings.Count(out, "Throwing a Pokeball"); n != 2 {
+		t.Errorf("expected one throw per Safari Ball, got %d in %q", n, out)
+	}
+	if n := strings.Count(out, "Try again?"); n != 1 {
+		t.Errorf("expected no retry once the balls ran out, got %d prompts in %q", n, out)
+	}
+	if !strings.Contains(out, "That was your last Safari Ball!\nSafari Game over: 0 caught with 2 Safari Balls\n") {
+		t.Errorf("expected the session to end with a summary, got %q", out)
+	}
+	if cfg.safari != nil {
+		t.Error("expected the session to be over")
+	}
+
+	cfg.safari = &safariSession{}
+	out = captureOutput(t, func() {
+		processInput("catch mewtwo", cfg)
+	})
+	if !strings.Contains(out, "You're out of Safari Balls!") || strings.Contains(out, "Throwing") {
+		t.Errorf("expected a catch without balls to be rejected, got %q", out)
+	}
+}
+
+func TestSafariRejectsBatchCatches(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.safari = &safariSession{balls: 1}
+	out := captureOutput(t, func() {
+		processInput("catch --range 1 3", cfg)
+		processInput("catch --area viridian-forest-area", cfg)
+	})
+	if n := strings.Count(out, "Catch Pokémon one at a time in the Safari Zone."); n != 2 || strings.Contains(out, "Throwing") {
+		t.Errorf("expected both batches to be rejected, got %q", out)
+	}
+	if cfg.safari == nil || cfg.safari.balls != 1 {
+		t.Error("expected the Safari session to be untouched")
+	}
+}
+
+func TestSafariSummary(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":0}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.rng = rand.New(rand.NewSource(6)) // first roll is 49
+	cfg.summary = true
+
+	out := captureOutput(t, func() {
+		processInput("safari start 5", cfg)
+		processInput("catch caterpie", cfg)
+		processInput("safari end", cfg)
+	})
+	if !strings.Contains(out, "CATCH name=caterpie chance=75 ") {
+		t.Errorf("expected a Safari Ball to raise 50%% to 75%%, got %q", out)
+	}
+	if !strings.Contains(out, "4 Safari Balls left") {
+		t.Errorf("expected the remaining balls to be shown, got %q", out)
+	}
+	if !strings.Contains(out, "Safari Game over: 1 caught with 1 Safari Balls (4 unused)\nCaught: caterpie\n") {
+		t.Errorf("expected a session summary, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("safari end", cfg)
+		processInput("safari start 99", cfg)
+	})
+	if !strings.Contains(out, "You're not in the Safari Zone.") || !strings.Contains(out, "count must be a number between 1 and 30") {
+		t.Errorf("expected validation messages, got %q", out)
+	}
+}
@@ -0,0 +1,48 @@
+package main
+
+import "context"
+
+// markSeen records that the user has encountered a Pokémon this session
+func markSeen(cfg *config, names ...string) {
+	if cfg.seen == nil {
+		cfg.seen = make(map[string]bool)
+	}
+	for _, name := range names {
+		cfg.seen[name] = true
+	}
+}
+
+// caughtAllSeen reports whether every Pokémon seen this session is in the pokedex
+func caughtAllSeen(cfg *config) bool {
+	if len(cfg.seen) == 0 {
+		return false
+	}
+	for name := range cfg.seen {
+		if _, ok := cfg.pokedex[name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// afterCatch runs once a Pokémon has been added to the pokedex
+func afterCatch(ctx context.Context, cfg *config, p Pokemon) {
+	if cfg.evoHints {
+		if hint := evolutionHint(ctx, cfg, p.Name); hint != "" {
+			flavorf(cfg, "%s\n", hint)
+		}
+	}
+	if cfg.typeFlavor {
+		if note := typeFlavor(ctx, cfg, p.Types); note != "" {
+			flavorf(cfg, "%s\n", note)
+		}
+	}
+
+	persistPokedex(cfg)
+	checkAchievements(ctx, cfg)
+
+	if !cfg.congratulated && caughtAllSeen(cfg) {
+		cfg.congratulated = true
+		flavorf(cfg, "Amazing! You've caught all %d Pokémon you've seen this session!\n", len(cfg.seen))
+	}
+}
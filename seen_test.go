@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCaughtAllSeenFiresOnce(t *testing.T) {
+	const message = "You've caught all"
+	cfg := newTestConfig(t)
+	markSeen(cfg, "oddish", "bellsprout")
+
+	// A 100% chance always succeeds, so each throw is a catch
+	out := captureOutput(t, func() {
+		throwBall(context.Background(), cfg, Pokemon{Name: "oddish"}, defaultBall, 100)
+	})
+	if strings.Contains(out, message) {
+		t.Fatalf("should not congratulate with bellsprout uncaught, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		throwBall(context.Background(), cfg, Pokemon{Name: "bellsprout"}, defaultBall, 100)
+	})
+	if !strings.Contains(out, "You've caught all 2 Pokémon") {
+		t.Fatalf("expected congratulations once everything seen is caught, got %q", out)
+	}
+
+	markSeen(cfg, "venonat")
+	out = captureOutput(t, func() {
+		throwBall(context.Background(), cfg, Pokemon{Name: "venonat"}, defaultBall, 100)
+	})
+	if strings.Contains(out, message) {
+		t.Errorf("congratulations should only fire once per session, got %q", out)
+	}
+}
+
+func TestExploreMarksSeen(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/location-area/route-1": `{"pokemon_encounters":[{"pokemon":{"name":"pidgey"}},{"pokemon":{"name":"rattata"}}]}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	captureOutput(t, func() {
+		processInput("explore route-1", cfg)
+	})
+	if !cfg.seen["pidgey"] || !cfg.seen["rattata"] {
+		t.Errorf("expected explored Pokémon to be marked seen, got %v", cfg.seen)
+	}
+}
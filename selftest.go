@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+// maxSelftestRolls bounds how many rolls a single selftest may simulate
+const maxSelftestRolls = 1_000_000
+
+// simulateCatches rolls n times against chance the same way throwBall does and returns the successes
+func simulateCatches(rng *rand.Rand, chance, n int) int {
+	successes := 0
+	for i := 0; i < n; i++ {
+		if rng.Intn(100)+1 <= chance {
+			successes++
+		}
+	}
+	return successes
+}
+
+// commandSelftest runs maintainer diagnostics; it is intentionally left out of help
+func commandSelftest(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) != 3 || args[0][0] != "catch" {
+		return userErrorf("Usage: selftest catch <pokemon-name> <n>")
+	}
+
+	name := args[0][1]
+	n, err := strconv.Atoi(args[0][2])
+	if err != nil || n < 1 || n > maxSelftestRolls {
+		return userErrorf("n must be a number between 1 and %d", maxSelftestRolls)
+	}
+
+	pokeResp, err := fetchPokemon(ctx, cfg, name)
+	if isNotFound(err) {
+		return userErrorf("Could not find Pokémon: %s", name)
+	}
+	if err != nil {
+		return err
+	}
+	chance, err := computeChance(ctx, cfg, pokeResp)
+	if err != nil {
+		return err
+	}
+
+	successes := simulateCatches(cfg.rng, chance, n)
+	rate := 100 * float64(successes) / float64(n)
+	fmt.Printf("%s: %d/%d caught (%.2f%%), expected %d%% (diff %+.2f)\n", pokeResp.Name, successes, n, rate, chance, rate-float64(chance))
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestSimulateCatchesMatchesChance(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	n := 100000
+	for _, chance := range []int{1, 25, 50, 90} {
+		rate := 100 * float64(simulateCatches(rng, chance, n)) / float64(n)
+		if math.Abs(rate-float64(chance)) > 1 {
+			t.Errorf("chance %d%%: empirical rate %.2f%% outside 1%% tolerance", chance, rate)
+		}
+	}
+}
+
+func TestSelftestCatch(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":39}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	out := captureOutput(t, func() {
+		processInput("selftest catch caterpie 1000", cfg)
+	})
+	if !strings.Contains(out, "caterpie: ") || !strings.Contains(out, "expected 31%") {
+		t.Errorf("unexpected selftest output %q", out)
+	}
+	if len(cfg.pokedex) != 0 {
+		t.Errorf("selftest must not modify the pokedex, got %v", cfg.pokedex)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("help", cfg)
+	})
+	if strings.Contains(out, "selftest") {
+		t.Error("selftest should be hidden from help")
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// newServeMux returns the read-only HTTP API used by -serve
+func newServeMux(cfg *config) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /pokedex", func(w http.ResponseWriter, r *http.Request) {
+		respondJSON(w, http.StatusOK, sortedPokedex(cfg.pokedex))
+	})
+
+	mux.HandleFunc("GET /pokedex/{name}", func(w http.ResponseWriter, r *http.Request) {
+		p, ok := cfg.pokedex[r.PathValue("name")]
+		if !ok {
+			respondJSON(w, http.StatusNotFound, map[string]string{"error": "not caught"})
+			return
+		}
+		respondJSON(w, http.StatusOK, p)
+	})
+
+	mux.HandleFunc("GET /pokemon/{name}", func(w http.ResponseWriter, r *http.Request) {
+		pokemonURL := fmt.Sprintf("%s/pokemon/%s", cfg.baseURL, url.PathEscape(r.PathValue("name")))
+		body, err := makeRequest(r.Context(), cfg, pokemonURL)
+		if err != nil {
+			respondJSON(w, http.StatusBadGateway, map[string]string{"error": err.Error()})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+
+	mux.HandleFunc("GET /metrics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		writeMetrics(w, cfg)
+	})
+
+	return mux
+}
+
+// respondJSON writes v as a JSON response with the given status code
+func respondJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		fmt.Printf("Error encoding response: %v\n", err)
+	}
+}
+
+// runServer serves the HTTP API on addr until ctx is cancelled
+func runServer(ctx context.Context, cfg *config, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           newServeMux(cfg),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- srv.ListenAndServe()
+	}()
+	fmt.Printf("Serving the Pokedex on %s\n", addr)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServePokedex(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.pokedex["pikachu"] = Pokemon{Name: "pikachu", BaseExperience: 112}
+	cfg.pokedex["eevee"] = Pokemon{Name: "eevee", BaseExperience: 65}
+	mux := newServeMux(cfg)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pokedex", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /pokedex: expected 200, got %d", rec.Code)
+	}
+	var list []Pokemon
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("GET /pokedex: invalid JSON: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "eevee" || list[1].Name != "pikachu" {
+		t.Errorf("GET /pokedex: unexpected list %+v", list)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pokedex/pikachu", nil))
+	var p Pokemon
+	if err := json.Unmarshal(rec.Body.Bytes(), &p); err != nil {
+		t.Fatalf("GET /pokedex/pikachu: invalid JSON: %v", err)
+	}
+	if rec.Code != http.StatusOK || p.BaseExperience != 112 {
+		t.Errorf("GET /pokedex/pikachu: got %d %+v", rec.Code, p)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pokedex/mew", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET /pokedex/mew: expected 404, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/pokedex", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST /pokedex: expected 405, got %d", rec.Code)
+	}
+}
+
+func TestServePokemonUsesCache(t *testing.T) {
+	calls := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"name":"ditto"}`))
+	}))
+	defer upstream.Close()
+
+	cfg := newTestConfig(t)
+	cfg.baseURL = upstream.URL
+	mux := newServeMux(cfg)
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pokemon/ditto", nil))
+		if rec.Code != http.StatusOK || rec.Body.String() != `{"name":"ditto"}` {
+			t.Errorf("GET /pokemon/ditto: got %d %q", rec.Code, rec.Body.String())
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 upstream call, got %d", calls)
+	}
+}
+
+func TestServeMetrics(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":0}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.endpoints = newEndpointStats()
+
+	captureOutput(t, func() {
+		processInput("catch caterpie", cfg) // seed 1 escapes
+		processInput("catch caterpie", cfg) // cache hit, seed 1 rolls 88
+	})
+
+	rec := httptest.NewRecorder()
+	newServeMux(cfg).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("GET /metrics: got %d %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE pokedex_requests_total counter\n",
+		`pokedex_requests_total{endpoint="/pokemon/{name}"} 1` + "\n",
+		"pokedex_cache_hits_total 1\n",
+		"pokedex_cache_misses_total 1\n",
+		"pokedex_cache_entries 1\n",
+		"pokedex_catch_attempts_total 2\n",
+		"pokedex_catches_total 0\n",
+		"pokedex_caught 0\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("GET /metrics: missing %q in %q", want, body)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// setting is a runtime option that set and get can change or read. Names
+// match the corresponding startup flags.
+type setting struct {
+	name        string
+	description string
+	get         func(cfg *config) string
+	set         func(cfg *config, value string) error
+}
+
+// boolSetting is an on/off setting backed by the config field field returns
+func boolSetting(name, description string, field func(cfg *config) *bool) setting {
+	return setting{
+		name:        name,
+		description: description,
+		get: func(cfg *config) string {
+			if *field(cfg) {
+				return "on"
+			}
+			return "off"
+		},
+		set: func(cfg *config, value string) error {
+			switch value {
+			case "on", "true", "yes", "1":
+				*field(cfg) = true
+			case "off", "false", "no", "0":
+				*field(cfg) = false
+			default:
+				return fmt.Errorf("%s must be on or off", name)
+			}
+			return nil
+		},
+	}
+}
+
+// durationSetting is a non-negative duration setting backed by the config field field returns
+func durationSetting(name, description string, field func(cfg *config) *time.Duration) setting {
+	return setting{
+		name:        name,
+		description: description,
+		get: func(cfg *config) string {
+			return field(cfg).String()
+		},
+		set: func(cfg *config, value string) error {
+			d, err := time.ParseDuration(value)
+			if err != nil || d < 0 {
+				return fmt.Errorf("%s must be a duration such as 30s or 5m", name)
+			}
+			*field(cfg) = d
+			return nil
+		},
+	}
+}
+
+// settings lists every runtime setting in the order config prints them
+var settings = []setting{
+	boolSetting("canonical-cache-keys", "ignore query parameter order when caching requests", func(cfg *config) *bool { return &cfg.canonicalizeCacheKeys }),
+	boolSetting("evo-hints", "after a catch, note what the Pokémon can still evolve into", func(cfg *config) *bool { return &cfg.evoHints }),
+	boolSetting("flavor", "after a catch, note what the Pokémon's types are strong against", func(cfg *config) *bool { return &cfg.typeFlavor }),
+	boolSetting("offline", "serve requests only from the cache and queue catches for a later sync", func(cfg *config) *bool { return &cfg.offline }),
+	boolSetting("persistence", "raise the catch chance after each failed throw at the same Pokémon", func(cfg *config) *bool { return &cfg.persistence }),
+	boolSetting("pretty", "format large numbers with thousands separators", func(cfg *config) *bool { return &cfg.pretty }),
+	boolSetting("quiet", "suppress flavor text and print only essential results", func(cfg *config) *bool { return &cfg.quiet }),
+	boolSetting("realistic", "compute catch chance with the Gen III+ capture formula", func(cfg *config) *bool { return &cfg.realistic }),
+	boolSetting("show-cache-age", "note when output was served from the cache and how old it is", func(cfg *config) *bool { return &cfg.showCacheAge }),
+	boolSetting("summary", "print a greppable summary line after each catch", func(cfg *config) *bool { return &cfg.summary }),
+	boolSetting("synergy", "give a catch bonus to Pokémon that cover your party's weaknesses", func(cfg *config) *bool { return &cfg.synergy }),
+	durationSetting("timeout-per-command", "abort batch commands such as catch --range after this long (0s disables)", func(cfg *config) *time.Duration { return &cfg.commandTimeout }),
+	boolSetting("timing", "time each throw by pressing Enter for a catch bonus (interactive only)", func(cfg *config) *bool { return &cfg.timing }),
+	boolSetting("verbose", "print diagnostics such as how long each command took", func(cfg *config) *bool { return &cfg.verbose }),
+}
+
+// findSetting looks up a setting by name
+func findSetting(name string) (setting, bool) {
+	for _, s := range settings {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return setting{}, false
+}
+
+// settingNames returns the names of all settings, for error messages
+func settingNames() string {
+	names := make([]string, 0, len(settings))
+	for _, s := range settings {
+		names = append(names, s.name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// commandSet changes a runtime setting: set <key> <value>
+func commandSet(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) != 2 {
+		return userErrorf("Usage: set <key> <value>")
+	}
+	key, value := args[0][0], args[0][1]
+	s, ok := findSetting(key)
+	if !ok {
+		return userErrorf("Unknown setting %q. Settings: %s", key, settingNames())
+	}
+	if err := s.set(cfg, value); err != nil {
+		return userErrorf("%v", err)
+	}
+	fmt.Printf("%s = %s\n", s.name, s.get(cfg))
+	return nil
+}
+
+// commandGet prints one runtime setting: get <key>
+func commandGet(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) != 1 {
+		return userErrorf("Usage: get <key>")
+	}
+	s, ok := findSetting(args[0][0])
+	if !ok {
+		return userErrorf("Unknown setting %q. Settings: %s", args[0][0], settingNames())
+	}
+	fmt.Printf("%s = %s\n", s.name, s.get(cfg))
+	return nil
+}
+
+// commandConfig prints every runtime setting and its current value
+func commandConfig(ctx context.Context, cfg *config, args ...[]string) error {
+	for _, s := range settings {
+		fmt.Printf("%s = %s  (%s)\n", s.name, s.get(cfg), s.description)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetAndGet(t *testing.T) {
+	cfg := newTestConfig(t)
+
+	out := captureOutput(t, func() {
+		processInput("set quiet on", cfg)
+		processInput("set timeout-per-command 15s", cfg)
+	})
+	if !cfg.quiet || cfg.commandTimeout != 15*time.Second {
+		t.Errorf("expected settings to change, got quiet=%v timeout=%v", cfg.quiet, cfg.commandTimeout)
+	}
+	if !strings.Contains(out, "quiet = on\n") || !strings.Contains(out, "timeout-per-command = 15s\n") {
+		t.Errorf("expected the new values to be printed, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("get quiet", cfg)
+	})
+	if out != "quiet = on\n" {
+		t.Errorf("unexpected get output %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("config", cfg)
+	})
+	if strings.Count(out, "\n") != len(settings) || !strings.Contains(out, "summary = off") {
+		t.Errorf("expected one line per setting, got %q", out)
+	}
+}
+
+func TestSetRejectsInvalid(t *testing.T) {
+	cfg := newTestConfig(t)
+	cases := map[string]string{
+		"set color on":                 `Unknown setting "color"`,
+		"set quiet maybe":              "quiet must be on or off",
+		"set timeout-per-command soon": "timeout-per-command must be a duration",
+		"set timeout-per-command -5s":  "timeout-per-command must be a duration",
+		"set quiet":                    "Usage: set <key> <value>",
+		"get difficulty":               `Unknown setting "difficulty"`,
+	}
+	for input, want := range cases {
+		out := captureOutput(t, func() {
+			processInput(input, cfg)
+		})
+		if !strings.Contains(out, want) {
+			t.Errorf("%s: expected %q, got %q", input, want, out)
+		}
+	}
+	if cfg.quiet || cfg.commandTimeout != 0 {
+		t.Errorf("invalid input should not change settings, got quiet=%v timeout=%v", cfg.quiet, cfg.commandTimeout)
+	}
+}
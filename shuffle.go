@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// commandShuffle prints the inspect summary of a random caught Pokémon,
+// optionally limited to one type with --type
+func commandShuffle(ctx context.Context, cfg *config, args ...[]string) error {
+	var rest []string
+	if len(args) > 0 {
+		rest = args[0]
+	}
+	rest, typeName, hasType, err := popFlagValue(rest, "type")
+	if err != nil || len(rest) > 0 || (hasType && typeName == "") {
+		return userErrorf("Usage: shuffle [--type <type>]")
+	}
+
+	// Pick from a sorted list so a seeded RNG always picks the same Pokémon
+	candidates := sortedPokedex(cfg.pokedex)
+	if hasType {
+		candidates = slices.DeleteFunc(candidates, func(p Pokemon) bool {
+			return !slices.Contains(p.Types, typeName)
+		})
+	}
+	if len(candidates) == 0 {
+		if hasType {
+			fmt.Printf("You haven't caught any %s-type Pokémon yet!\n", typeName)
+		} else {
+			fmt.Println("You haven't caught any Pokémon yet!")
+		}
+		return nil
+	}
+
+	printPokemon(candidates[cfg.rng.Intn(len(candidates))])
+	return nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestShuffle(t *testing.T) {
+	cfg := newTestConfig(t)
+
+	out := captureOutput(t, func() {
+		processInput("shuffle", cfg)
+	})
+	if !strings.Contains(out, "You haven't caught any Pokémon yet!") {
+		t.Errorf("expected empty pokedex message, got %q", out)
+	}
+
+	cfg.pokedex = map[string]Pokemon{
+		"bulbasaur":  {Name: "bulbasaur", Types: []string{"grass", "poison"}},
+		"charmander": {Name: "charmander", Types: []string{"fire"}},
+		"oddish":     {Name: "oddish", Types: []string{"grass", "poison"}},
+		"squirtle":   {Name: "squirtle", Types: []string{"water"}},
+	}
+
+	// Seed 1's first Intn(4) is 1, picking the second name in sorted order
+	out = captureOutput(t, func() {
+		processInput("shuffle", cfg)
+	})
+	if !strings.Contains(out, "Name: charmander\n") {
+		t.Errorf("expected charmander with seed 1, got %q", out)
+	}
+
+	for range 5 {
+		out = captureOutput(t, func() {
+			processInput("shuffle --type grass", cfg)
+		})
+		if !strings.Contains(out, "Name: bulbasaur\n") && !strings.Contains(out, "Name: oddish\n") {
+			t.Errorf("expected a grass-type Pokémon, got %q", out)
+		}
+	}
+
+	out = captureOutput(t, func() {
+		processInput("shuffle --type dragon", cfg)
+	})
+	if !strings.Contains(out, "You haven't caught any dragon-type Pokémon yet!") {
+		t.Errorf("expected no-match message, got %q", out)
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxSimulatedThrows caps simulate so a typo can't fill the disk
+const maxSimulatedThrows = 1_000_000
+
+// commandSimulate throws n simulated balls at a Pokémon with cfg.rng, without
+// touching the pokedex, and writes one CSV row per throw to a file
+func commandSimulate(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) < 3 {
+		return userErrorf("Usage: simulate <pokemon-name> <n> <file>")
+	}
+	name, nArg, path := strings.ToLower(args[0][0]), args[0][1], args[0][2]
+
+	n, err := strconv.Atoi(nArg)
+	if err != nil || n < 1 || n > maxSimulatedThrows {
+		return userErrorf("n must be a number between 1 and %d", maxSimulatedThrows)
+	}
+	if info, err := os.Stat(filepath.Dir(path)); err != nil || !info.IsDir() {
+		return userErrorf("Directory %s does not exist", filepath.Dir(path))
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return userErrorf("%s is a directory", path)
+	}
+
+	pokeResp, err := fetchPokemon(ctx, cfg, name)
+	if isNotFound(err) {
+		return userErrorf("Could not find Pokémon: %s", name)
+	}
+	if err != nil {
+		return err
+	}
+	chance, err := computeChance(ctx, cfg, pokeResp)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"throw", "roll", "chance", "outcome"})
+	caught := 0
+	for i := 1; i <= n; i++ {
+		roll := cfg.rng.Intn(100) + 1 // 1-100, as in throwBall
+		outcome := "escaped"
+		if roll <= chance {
+			outcome = "caught"
+			caught++
+		}
+		w.Write([]string{strconv.Itoa(i), strconv.Itoa(roll), strconv.Itoa(chance), outcome})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("error encoding simulation: %w", err)
+	}
+	if err := atomicWrite(path, buf.Bytes()); err != nil {
+		return fmt.Errorf("error writing simulation file: %w", err)
+	}
+
+	fmt.Printf("Simulated %d throws at %s: caught %d (%.1f%%, expected %d%%). Wrote %s\n",
+		n, pokeResp.Name, caught, 100*float64(caught)/float64(n), chance, path)
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/csv"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSimulate(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pikachu": `{"name":"pikachu","base_experience":40}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	path := filepath.Join(t.TempDir(), "sim.csv")
+
+	out := captureOutput(t, func() {
+		processInput("simulate pikachu 2000 "+path, cfg)
+	})
+	if len(cfg.pokedex) != 0 {
+		t.Errorf("simulate should not catch anything, got %v", cfg.pokedex)
+	}
+	if !strings.Contains(out, "Simulated 2000 throws at pikachu") || !strings.Contains(out, "expected 30%") {
+		t.Errorf("expected a summary line, got %q", out)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2001 {
+		t.Fatalf("expected a header and 2000 rows, got %d rows", len(rows))
+	}
+	caught := 0
+	for _, row := range rows[1:] {
+		if row[3] == "caught" {
+			caught++
+		}
+	}
+	if rate := float64(caught) / 2000; math.Abs(rate-0.30) > 0.03 {
+		t.Errorf("expected a catch rate near 30%%, got %.3f", rate)
+	}
+}
+
+func TestSimulateValidation(t *testing.T) {
+	cfg := newTestConfig(t)
+	dir := t.TempDir()
+	cases := map[string]string{
+		"simulate pikachu":                                "Usage: simulate",
+		"simulate pikachu lots " + dir + "/sim.csv":       "n must be a number",
+		"simulate pikachu 0 " + dir + "/sim.csv":          "n must be a number",
+		"simulate pikachu 10 " + dir + "/missing/sim.csv": "does not exist",
+		"simulate pikachu 10 " + dir:                      "is a directory",
+		"simulate pikachu 10000000 " + dir + "/sim.csv":   "n must be a number",
+	}
+	for input, want := range cases {
+		out := captureOutput(t, func() {
+			processInput(input, cfg)
+		})
+		if !strings.Contains(out, want) {
+			t.Errorf("%q: expected %q, got %q", input, want, out)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+const (
+	// statsBucketWidth is the base-experience range covered by each histogram bar
+	statsBucketWidth = 50
+	// statsBarWidth is the length of the bar for the largest bucket
+	statsBarWidth = 40
+)
+
+// expBucket counts caught Pokémon with base experience in [low, high]
+type expBucket struct {
+	low, high int
+	count     int
+}
+
+// baseExpHistogram buckets the pokedex by base experience, from 0 up to the
+// bucket holding the highest value. Empty buckets in between are kept so the
+// chart's axis is continuous.
+func baseExpHistogram(pokedex map[string]Pokemon) []expBucket {
+	if len(pokedex) == 0 {
+		return nil
+	}
+	highest := 0
+	for _, p := range pokedex {
+		highest = max(highest, p.BaseExperience)
+	}
+	buckets := make([]expBucket, highest/statsBucketWidth+1)
+	for i := range buckets {
+		buckets[i].low = i * statsBucketWidth
+		buckets[i].high = buckets[i].low + statsBucketWidth - 1
+	}
+	for _, p := range pokedex {
+		buckets[max(p.BaseExperience, 0)/statsBucketWidth].count++
+	}
+	return buckets
+}
+
+// commandStats summarizes the pokedex; "stats graph" draws a base experience histogram
+func commandStats(ctx context.Context, cfg *config, args ...[]string) error {
+	var sub string
+	if len(args) > 0 && len(args[0]) > 0 {
+		sub = args[0][0]
+	}
+	if sub != "" && sub != "graph" && sub != "--graph" {
+		return userErrorf("Usage: stats [graph]")
+	}
+
+	if len(cfg.pokedex) == 0 {
+		fmt.Println("Your Pokedex is empty. Go catch some Pokémon!")
+		return nil
+	}
+
+	if sub == "" {
+		total := 0
+		for _, p := range cfg.pokedex {
+			total += p.BaseExperience
+		}
+		fmt.Printf("Caught: %s\n", formatCount(cfg, len(cfg.pokedex)))
+		fmt.Printf("Total base experience: %s\n", formatCount(cfg, total))
+		fmt.Printf("Average base experience: %s\n", formatCount(cfg, total/len(cfg.pokedex)))
+		return nil
+	}
+
+	buckets := baseExpHistogram(cfg.pokedex)
+	largest := 0
+	for _, b := range buckets {
+		largest = max(largest, b.count)
+	}
+	labelWidth := len(fmt.Sprintf("%d-%d", buckets[len(buckets)-1].low, buckets[len(buckets)-1].high))
+
+	fmt.Println("Base experience:")
+	for _, b := range buckets {
+		bar := strings.Repeat("#", (b.count*statsBarWidth+largest-1)/largest)
+		fmt.Printf("%*s | %s %d\n", labelWidth, fmt.Sprintf("%d-%d", b.low, b.high), bar, b.count)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBaseExpHistogram(t *testing.T) {
+	pokedex := map[string]Pokemon{
+		"caterpie":   {Name: "caterpie", BaseExperience: 39},
+		"pidgey":     {Name: "pidgey", BaseExperience: 50},
+		"rattata":    {Name: "rattata", BaseExperience: 51},
+		"pikachu":    {Name: "pikachu", BaseExperience: 112},
+		"charmander": {Name: "charmander", BaseExperience: 62},
+		"mewtwo":     {Name: "mewtwo", BaseExperience: 340},
+	}
+
+	buckets := baseExpHistogram(pokedex)
+	want := []int{1, 3, 1, 0, 0, 0, 1}
+	if len(buckets) != len(want) {
+		t.Fatalf("expected %d buckets, got %d: %v", len(want), len(buckets), buckets)
+	}
+	for i, b := range buckets {
+		if b.count != want[i] || b.low != i*statsBucketWidth {
+			t.Errorf("bucket %d: expected %d from %d, got %d from %d", i, want[i], i*statsBucketWidth, b.count, b.low)
+		}
+	}
+
+	cfg := newTestConfig(t)
+	cfg.pokedex = pokedex
+	out := captureOutput(t, func() {
+		processInput("stats graph", cfg)
+	})
+	if !strings.Contains(out, "  50-99 | "+strings.Repeat("#", statsBarWidth)+" 3\n") {
+		t.Errorf("expected the largest bucket to have a full bar, got %q", out)
+	}
+	if !strings.Contains(out, "150-199 |  0\n") {
+		t.Errorf("expected empty buckets to be kept, got %q", out)
+	}
+
+	if got := baseExpHistogram(nil); got != nil {
+		t.Errorf("expected no buckets for an empty pokedex, got %v", got)
+	}
+}
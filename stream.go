@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// NamedResource is an entry in a PokeAPI paginated list
+type NamedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// listPage holds the pagination fields of a PokeAPI list response
+type listPage struct {
+	Count    int
+	Next     *string
+	Previous *string
+}
+
+// streamList decodes a PokeAPI list response from r, calling fn for each
+// entry in "results" as it is decoded instead of materializing the whole
+// slice. Unknown fields are skipped.
+func streamList(r io.Reader, fn func(NamedResource) error) (listPage, error) {
+	var page listPage
+	dec := json.NewDecoder(r)
+
+	if err := expectDelim(dec, '{'); err != nil {
+		return page, err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return page, fmt.Errorf("error decoding list: %w", err)
+		}
+		key, _ := tok.(string)
+
+		switch key {
+		case "count":
+			err = dec.Decode(&page.Count)
+		case "next":
+			err = dec.Decode(&page.Next)
+		case "previous":
+			err = dec.Decode(&page.Previous)
+		case "results":
+			err = streamResults(dec, fn)
+		default:
+			var skip json.RawMessage
+			err = dec.Decode(&skip)
+		}
+		if err != nil {
+			return page, fmt.Errorf("error decoding list field %q: %w", key, err)
+		}
+	}
+	return page, expectDelim(dec, '}')
+}
+
+// streamResults decodes the "results" array one element at a time
+func streamResults(dec *json.Decoder, fn func(NamedResource) error) error {
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+	for dec.More() {
+		var item NamedResource
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return expectDelim(dec, ']')
+}
+
+// expectDelim reads the next token and checks that it is the delimiter want
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("error decoding list: %w", err)
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("error decoding list: expected %q, got %v", want, tok)
+	}
+	return nil
+}
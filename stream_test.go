@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestStreamList(t *testing.T) {
+	body := `{"count":3,"next":"https://example.test/next","previous":null,
+		"extra":{"ignored":[1,2,3]},
+		"results":[{"name":"a","url":"u1"},{"name":"b","url":"u2"},{"name":"c","url":"u3"}]}`
+
+	var names []string
+	page, err := streamList(strings.NewReader(body), func(r NamedResource) error {
+		names = append(names, r.Name)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("streamList: %v", err)
+	}
+	if page.Count != 3 || page.Next == nil || *page.Next != "https://example.test/next" || page.Previous != nil {
+		t.Errorf("unexpected page %+v", page)
+	}
+	if strings.Join(names, ",") != "a,b,c" {
+		t.Errorf("expected a,b,c, got %v", names)
+	}
+
+	if _, err := streamList(strings.NewReader(`{"results":[{"name":"a"}`), func(NamedResource) error { return nil }); err == nil {
+		t.Error("expected an error for truncated input")
+	}
+}
+
+// largeListBody builds a list response shaped like the full species list
+func largeListBody(n int) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `{"count":%d,"next":null,"previous":null,"results":[`, n)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, `{"name":"species-%d","url":"https://pokeapi.co/api/v2/pokemon-species/%d/"}`, i, i)
+	}
+	buf.WriteString("]}")
+	return buf.Bytes()
+}
+
+func BenchmarkListUnmarshal(b *testing.B) {
+	body := largeListBody(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var resp LocationAreasResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkListStream(b *testing.B) {
+	body := largeListBody(100000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		count := 0
+		_, err := streamList(bytes.NewReader(body), func(NamedResource) error {
+			count++
+			return nil
+		})
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"sort"
+	"strings"
+)
+
+// maxTeamSize is the number of Pokémon in a battle party
+const maxTeamSize = 6
+
+// TypeResponse is the subset of the /type/{name} response we decode
+type TypeResponse struct {
+	Name            string `json:"name"`
+	DamageRelations struct {
+		DoubleDamageTo []struct {
+			Name string `json:"name"`
+		} `json:"double_damage_to"`
+		DoubleDamageFrom []struct {
+			Name string `json:"name"`
+		} `json:"double_damage_from"`
+	} `json:"damage_relations"`
+}
+
+// fetchType fetches and decodes the (cached) /type/{name} endpoint
+func fetchType(ctx context.Context, cfg *config, typeName string) (TypeResponse, error) {
+	url := fmt.Sprintf("%s/type/%s", cfg.baseURL, neturl.PathEscape(typeName))
+	typeResp, err := getJSON[TypeResponse](ctx, cfg, url)
+	if err != nil {
+		return typeResp, fmt.Errorf("failed to fetch type data: %w", err)
+	}
+	return typeResp, nil
+}
+
+// fetchSuperEffective returns the types that typeName deals double damage to
+func fetchSuperEffective(ctx context.Context, cfg *config, typeName string) ([]string, error) {
+	typeResp, err := fetchType(ctx, cfg, typeName)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]string, 0, len(typeResp.DamageRelations.DoubleDamageTo))
+	for _, t := range typeResp.DamageRelations.DoubleDamageTo {
+		targets = append(targets, t.Name)
+	}
+	return targets, nil
+}
+
+// fetchWeaknesses returns the types that deal double damage to typeName
+func fetchWeaknesses(ctx context.Context, cfg *config, typeName string) ([]string, error) {
+	typeResp, err := fetchType(ctx, cfg, typeName)
+	if err != nil {
+		return nil, err
+	}
+	attackers := make([]string, 0, len(typeResp.DamageRelations.DoubleDamageFrom))
+	for _, t := range typeResp.DamageRelations.DoubleDamageFrom {
+		attackers = append(attackers, t.Name)
+	}
+	return attackers, nil
+}
+
+// teamMember is a suggested Pokémon and the types it is super effective against
+type teamMember struct {
+	Name     string
+	Types    []string
+	Coverage []string
+}
+
+// suggestTeam greedily picks up to maxTeamSize Pokémon, each time taking the one
+// that adds the most newly covered types (ties broken by name). superEffective
+// maps an attacking type to the types it deals double damage to.
+func suggestTeam(pokedex map[string]Pokemon, superEffective map[string][]string) ([]teamMember, []string) {
+	candidates := make(map[string]map[string]bool, len(pokedex))
+	for name, p := range pokedex {
+		covers := make(map[string]bool)
+		for _, t := range p.Types {
+			for _, target := range superEffective[t] {
+				covers[target] = true
+			}
+		}
+		candidates[name] = covers
+	}
+
+	covered := make(map[string]bool)
+	var team []teamMember
+	for len(team) < maxTeamSize && len(candidates) > 0 {
+		best, bestGain := "", -1
+		for name, covers := range candidates {
+			gain := 0
+			for target := range covers {
+				if !covered[target] {
+					gain++
+				}
+			}
+			if gain > bestGain || (gain == bestGain && name < best) {
+				best, bestGain = name, gain
+			}
+		}
+		if bestGain == 0 && len(team) > 0 {
+			break
+		}
+
+		coverage := sortedKeys(candidates[best])
+		for _, target := range coverage {
+			covered[target] = true
+		}
+		team = append(team, teamMember{Name: best, Types: pokedex[best].Types, Coverage: coverage})
+		delete(candidates, best)
+	}
+	return team, sortedKeys(covered)
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// commandTeam dispatches the team subcommands
+func commandTeam(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) == 0 || args[0][0] != "suggest" {
+		return userErrorf("Usage: team suggest")
+	}
+
+	if len(cfg.pokedex) == 0 {
+		fmt.Println("You haven't caught any Pokémon yet!")
+		return nil
+	}
+
+	superEffective := make(map[string][]string)
+	for _, p := range cfg.pokedex {
+		for _, t := range p.Types {
+			if _, ok := superEffective[t]; ok {
+				continue
+			}
+			targets, err := fetchSuperEffective(ctx, cfg, t)
+			if err != nil {
+				return err
+			}
+			superEffective[t] = targets
+		}
+	}
+
+	team, covered := suggestTeam(cfg.pokedex, superEffective)
+	if len(cfg.pokedex) < maxTeamSize {
+		fmt.Printf("You only have %d Pokémon to choose from.\n", len(cfg.pokedex))
+	}
+	fmt.Println("Suggested team:")
+	for _, m := range team {
+		fmt.Printf(" - %s (%s): %s\n", m.Name, strings.Join(m.Types, "/"), strings.Join(m.Coverage, ", "))
+	}
+	fmt.Printf("Super effective against %d types: %s\n", len(covered), strings.Join(covered, ", "))
+	return nil
+}
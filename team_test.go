@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSuggestTeam(t *testing.T) {
+	pokedex := map[string]Pokemon{
+		"charmander": {Name: "charmander", Types: []string{"fire"}},
+		"vulpix":     {Name: "vulpix", Types: []string{"fire"}},
+		"squirtle":   {Name: "squirtle", Types: []string{"water"}},
+		"geodude":    {Name: "geodude", Types: []string{"rock", "ground"}},
+	}
+	superEffective := map[string][]string{
+		"fire":   {"grass", "ice", "bug", "steel"},
+		"water":  {"fire", "ground", "rock"},
+		"rock":   {"fire", "ice", "flying", "bug"},
+		"ground": {"fire", "electric", "poison", "rock", "steel"},
+	}
+
+	team, covered := suggestTeam(pokedex, superEffective)
+
+	var names []string
+	for _, m := range team {
+		names = append(names, m.Name)
+	}
+	// geodude covers 8 types; charmander (grass) and squirtle (ground) each add
+	// one, tie-broken by name; vulpix adds nothing new and is left out
+	if got, want := strings.Join(names, ","), "geodude,charmander,squirtle"; got != want {
+		t.Errorf("expected team %s, got %s", want, got)
+	}
+	if len(covered) != 10 {
+		t.Errorf("expected 10 covered types, got %d: %v", len(covered), covered)
+	}
+}
+
+func TestTeamSuggestCommand(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/type/electric": `{"name":"electric","damage_relations":{"double_damage_to":[{"name":"water"},{"name":"flying"}]}}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.pokedex["pikachu"] = Pokemon{Name: "pikachu", Types: []string{"electric"}}
+
+	out := captureOutput(t, func() {
+		processInput("team suggest", cfg)
+	})
+	for _, want := range []string{
+		"You only have 1 Pokémon to choose from.",
+		" - pikachu (electric): flying, water",
+		"Super effective against 2 types: flying, water",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output %q", want, out)
+		}
+	}
+
+	empty := newTestConfig(t)
+	out = captureOutput(t, func() {
+		processInput("team suggest", empty)
+	})
+	if !strings.Contains(out, "You haven't caught any Pokémon yet!") {
+		t.Errorf("expected empty pokedex message, got %q", out)
+	}
+}
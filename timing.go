@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timing minigame (-timing): after the throw, pressing Enter within a random
+// window earns timingBonus; missing it costs timingPenalty.
+const (
+	timingBonus     = 10
+	timingPenalty   = 5
+	timingMinWindow = 700 * time.Millisecond
+	timingMaxWindow = 1500 * time.Millisecond
+)
+
+// timeThrow runs the timing minigame and returns the adjusted catch chance.
+// It needs a person at the keyboard, so outside interactive mode it returns
+// chance unchanged without reading input, as it does for a guaranteed catch
+// and inside a batch, whose cancel watcher owns the input.
+func timeThrow(cfg *config, chance int) int {
+	if !cfg.timing || !cfg.interactive || cfg.lines == nil || cfg.inBatch || chance >= 100 {
+		return chance
+	}
+
+	window := timingMinWindow + time.Duration(cfg.rng.Int63n(int64(timingMaxWindow-timingMinWindow)))
+	fmt.Print("Press Enter to time your throw! ")
+	select {
+	case _, ok := <-cfg.lines:
+		if ok {
+			fmt.Printf("Nice timing! +%d%%\n", timingBonus)
+			return min(chance+timingBonus, 100)
+		}
+	case <-time.After(window):
+		fmt.Println()
+	}
+	fmt.Printf("Too slow! -%d%%\n", timingPenalty)
+	return max(chance-timingPenalty, 1)
+}
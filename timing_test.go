@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTimingSkippedWithoutTTY(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/caterpie": `{"name":"caterpie","base_experience":0}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	cfg.summary = true
+	cfg.timing = true
+	lines := make(chan string, 1)
+	lines <- ""
+	cfg.lines = lines
+
+	out := captureOutput(t, func() {
+		processInput("catch caterpie", cfg)
+	})
+	if strings.Contains(out, "time your throw") {
+		t.Errorf("expected no timing prompt without a terminal, got %q", out)
+	}
+	if !strings.Contains(out, "CATCH name=caterpie chance=50 roll=82") {
+		t.Errorf("expected the unadjusted chance and roll, got %q", out)
+	}
+	if len(lines) != 1 {
+		t.Error("expected input to be left unread")
+	}
+}
+
+func TestTimingBonus(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.timing = true
+	cfg.interactive = true
+	lines := make(chan string, 1)
+	lines <- ""
+	cfg.lines = lines
+
+	var chance int
+	out := captureOutput(t, func() {
+		chance = timeThrow(cfg, 50)
+	})
+	if chance != 50+timingBonus || !strings.Contains(out, "Nice timing!") {
+		t.Errorf("expected a timing bonus, got chance %d and output %q", chance, out)
+	}
+}
+
+func TestTimingSkippedInBatch(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.timing = true
+	cfg.interactive = true
+	cfg.lines = make(chan string) // owned by the batch's cancel watcher
+
+	var chance int
+	out := captureOutput(t, func() {
+		runBatch(context.Background(), cfg, func(ctx context.Context) error {
+			chance = timeThrow(cfg, 50)
+			return nil
+		})
+	})
+	if chance != 50 || strings.Contains(out, "time your throw") {
+		t.Errorf("expected no timing inside a batch, got chance %d and output %q", chance, out)
+	}
+	if cfg.inBatch {
+		t.Error("expected inBatch to be cleared once the batch ends")
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"strings"
+	"time"
+)
+
+// requestTiming records the phases of one HTTP request for -trace
+type requestTiming struct {
+	start        time.Time
+	dnsStart     time.Time
+	dnsDone      time.Time
+	connectStart time.Time
+	connectDone  time.Time
+	tlsStart     time.Time
+	tlsDone      time.Time
+	firstByte    time.Time
+	reused       bool
+}
+
+// clientTrace returns hooks that fill in rt as the request progresses
+func (rt *requestTiming) clientTrace() *httptrace.ClientTrace {
+	return &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			rt.reused = info.Reused
+		},
+		DNSStart: func(httptrace.DNSStartInfo) {
+			rt.dnsStart = time.Now()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			rt.dnsDone = time.Now()
+		},
+		ConnectStart: func(network, addr string) {
+			rt.connectStart = time.Now()
+		},
+		ConnectDone: func(network, addr string, err error) {
+			rt.connectDone = time.Now()
+		},
+		TLSHandshakeStart: func() {
+			rt.tlsStart = time.Now()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			rt.tlsDone = time.Now()
+		},
+		GotFirstResponseByte: func() {
+			rt.firstByte = time.Now()
+		},
+	}
+}
+
+// String summarizes the phases that happened, e.g. "dns=3ms connect=12ms tls=40ms first-byte=95ms"
+func (rt *requestTiming) String() string {
+	var parts []string
+	phase := func(name string, from, to time.Time) {
+		if !from.IsZero() && !to.IsZero() {
+			parts = append(parts, fmt.Sprintf("%s=%s", name, to.Sub(from).Round(time.Millisecond)))
+		}
+	}
+	phase("dns", rt.dnsStart, rt.dnsDone)
+	phase("connect", rt.connectStart, rt.connectDone)
+	phase("tls", rt.tlsStart, rt.tlsDone)
+	phase("first-byte", rt.start, rt.firstByte)
+	if rt.reused {
+		parts = append(parts, "reused-conn")
+	}
+	return strings.Join(parts, " ")
+}
+
+// tracing reports whether -trace output is on, which also takes -verbose
+func tracing(cfg *config) bool {
+	return cfg.trace != nil && cfg.verbose
+}
+
+// httpGet GETs url with cfg's client, tracing the request's phases to cfg.trace
+// when -trace and -verbose are both set
+func httpGet(ctx context.Context, cfg *config, url string) (*http.Response, error) {
+	client := cfg.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !tracing(cfg) {
+		return client.Do(req)
+	}
+
+	timing := &requestTiming{start: time.Now()}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), timing.clientTrace()))
+	resp, err := client.Do(req)
+	fmt.Fprintf(cfg.trace, "trace %s %s\n", url, timing)
+	return resp, err
+}
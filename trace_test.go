@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTrace(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pikachu": `{"name":"pikachu"}`,
+	})
+	cfg := newTestConfig(t)
+	var trace bytes.Buffer
+	cfg.trace = &trace
+
+	url := srv.URL + "/pokemon/pikachu"
+	makeRequest(context.Background(), cfg, url)
+	if trace.Len() != 0 {
+		t.Errorf("expected no timings without -verbose, got %q", trace.String())
+	}
+	cfg.cache.Delete(url)
+
+	cfg.verbose = true
+	if _, err := makeRequest(context.Background(), cfg, url); err != nil {
+		t.Fatalf("makeRequest: %v", err)
+	}
+	line := trace.String()
+	if !strings.HasPrefix(line, "trace "+url+" ") {
+		t.Fatalf("expected a trace line for %s, got %q", url, line)
+	}
+	// The mock server is reached by IP over plain HTTP: no DNS or TLS phases
+	if !strings.Contains(line, "first-byte=") || strings.Contains(line, "tls=") {
+		t.Errorf("unexpected phases in %q", line)
+	}
+
+	timing := &requestTiming{}
+	ct := timing.clientTrace()
+	ct.ConnectStart("tcp", "127.0.0.1:80")
+	ct.ConnectDone("tcp", "127.0.0.1:80", nil)
+	ct.GotFirstResponseByte()
+	if timing.connectStart.IsZero() || timing.connectDone.IsZero() || timing.firstByte.IsZero() {
+		t.Errorf("expected the callbacks to record timestamps, got %+v", timing)
+	}
+
+	// Cache hits make no request, so there is nothing to trace
+	trace.Reset()
+	makeRequest(context.Background(), cfg, url)
+	if trace.Len() != 0 {
+		t.Errorf("expected no trace for a cache hit, got %q", trace.String())
+	}
+}
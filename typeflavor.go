@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// typeFlavor returns a cosmetic note on what each of types is strong against,
+// e.g. "Fire types are strong against Grass, Ice, Bug and Steel". Types whose
+// data can't be fetched are left out; "" means there was nothing to say.
+func typeFlavor(ctx context.Context, cfg *config, types []string) string {
+	notes := make([]string, 0, len(types))
+	for _, typeName := range types {
+		targets, err := fetchSuperEffective(ctx, cfg, typeName)
+		if err != nil {
+			continue
+		}
+		if len(targets) == 0 {
+			notes = append(notes, fmt.Sprintf("%s types aren't strong against any type", capitalize(typeName)))
+			continue
+		}
+		for i, target := range targets {
+			targets[i] = capitalize(target)
+		}
+		notes = append(notes, fmt.Sprintf("%s types are strong against %s", capitalize(typeName), joinAnd(targets)))
+	}
+	return strings.Join(notes, "; ")
+}
+
+// joinAnd joins names as "a, b and c"
+func joinAnd(names []string) string {
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// capitalize upper-cases the first letter of s
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	if r == utf8.RuneError {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[size:]
+}
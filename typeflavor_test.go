@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTypeFlavor(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/type/fire":   `{"name":"fire","damage_relations":{"double_damage_to":[{"name":"grass"},{"name":"ice"},{"name":"bug"},{"name":"steel"}]}}`,
+		"/type/flying": `{"name":"flying","damage_relations":{"double_damage_to":[{"name":"grass"},{"name":"fighting"},{"name":"bug"}]}}`,
+		"/type/normal": `{"name":"normal","damage_relations":{"double_damage_to":[]}}`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+	catch := func(p Pokemon) string {
+		return captureOutput(t, func() {
+			throwBall(context.Background(), cfg, p, defaultBall, 100)
+		})
+	}
+
+	if out := catch(Pokemon{Name: "vulpix", Types: []string{"fire"}}); strings.Contains(out, "strong against") {
+		t.Errorf("expected no flavor without -flavor, got %q", out)
+	}
+
+	cfg.typeFlavor = true
+	cases := []struct {
+		pokemon Pokemon
+		want    string
+	}{
+		{Pokemon{Name: "ponyta", Types: []string{"fire"}}, "Fire types are strong against Grass, Ice, Bug and Steel\n"},
+		{Pokemon{Name: "charizard", Types: []string{"fire", "flying"}}, "Fire types are strong against Grass, Ice, Bug and Steel; Flying types are strong against Grass, Fighting and Bug\n"},
+		{Pokemon{Name: "rattata", Types: []string{"normal"}}, "Normal types aren't strong against any type\n"},
+		{Pokemon{Name: "missingno", Types: []string{"bird"}}, ""},
+	}
+	for _, c := range cases {
+		out := catch(c.pokemon)
+		if c.want == "" {
+			if strings.Contains(out, "strong against") {
+				t.Errorf("%s: expected no flavor for an unknown type, got %q", c.pokemon.Name, out)
+			}
+			continue
+		}
+		if !strings.Contains(out, c.want) {
+			t.Errorf("%s: expected %q, got %q", c.pokemon.Name, c.want, out)
+		}
+	}
+
+	cfg.quiet = true
+	if out := catch(Pokemon{Name: "growlithe", Types: []string{"fire"}}); strings.Contains(out, "strong against") {
+		t.Errorf("-quiet should suppress flavor, got %q", out)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"sort"
+)
+
+// PokemonEncountersResponse is the /pokemon/{name}/encounters response
+type PokemonEncountersResponse []struct {
+	LocationArea struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"location_area"`
+}
+
+// commandWhereis lists the location areas where a Pokémon can be encountered
+func commandWhereis(ctx context.Context, cfg *config, args ...[]string) error {
+	if len(args) == 0 || len(args[0]) == 0 {
+		return userErrorf("You must provide a Pokémon name")
+	}
+
+	pokemonName := args[0][0]
+	url := fmt.Sprintf("%s/pokemon/%s/encounters", cfg.baseURL, neturl.PathEscape(pokemonName))
+
+	// Use cached request
+	encounters, err := getJSON[PokemonEncountersResponse](ctx, cfg, url)
+	if err != nil {
+		return fmt.Errorf("failed to fetch encounter data: %w", err)
+	}
+
+	seen := make(map[string]bool, len(encounters))
+	areas := make([]string, 0, len(encounters))
+	for _, e := range encounters {
+		if !seen[e.LocationArea.Name] {
+			seen[e.LocationArea.Name] = true
+			areas = append(areas, e.LocationArea.Name)
+		}
+	}
+	sort.Strings(areas)
+
+	if len(areas) == 0 {
+		fmt.Printf("%s is not found in the wild\n", pokemonName)
+		return nil
+	}
+
+	fmt.Printf("%s can be found in:\n", pokemonName)
+	for _, area := range areas {
+		fmt.Printf(" - %s\n", area)
+	}
+	return nil
+}
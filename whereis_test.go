@@ -0,0 +1,34 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWhereis(t *testing.T) {
+	srv := newTestServer(t, map[string]string{
+		"/pokemon/pikachu/encounters": `[
+			{"location_area":{"name":"viridian-forest-area"}},
+			{"location_area":{"name":"power-plant-area"}},
+			{"location_area":{"name":"viridian-forest-area"}}
+		]`,
+		"/pokemon/mew/encounters": `[]`,
+	})
+	cfg := newTestConfig(t)
+	cfg.baseURL = srv.URL
+
+	out := captureOutput(t, func() {
+		processInput("whereis pikachu", cfg)
+	})
+	want := "pikachu can be found in:\n - power-plant-area\n - viridian-forest-area\n"
+	if out != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+
+	out = captureOutput(t, func() {
+		processInput("whereis mew", cfg)
+	})
+	if !strings.Contains(out, "mew is not found in the wild") {
+		t.Errorf("expected not-found message, got %q", out)
+	}
+}